@@ -39,6 +39,10 @@ func ExecuteValidateConfig(configPath string) {
 		logger.GetDefaultLogger().Panic().Err(err).Msg("Provided config is invalid!")
 	}
 
+	for _, warning := range config.Warnings {
+		logger.GetDefaultLogger().Warn().Msg(warning)
+	}
+
 	logger.GetDefaultLogger().Info().Msg("Provided config is valid.")
 }
 
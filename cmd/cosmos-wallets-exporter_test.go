@@ -47,6 +47,19 @@ func TestValidateConfigInvalid(t *testing.T) {
 	assert.True(t, true)
 }
 
+//nolint:paralleltest // disabled
+func TestValidateConfigInvalidAddress(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	os.Args = []string{"cmd", "validate-config", "--config", "../assets/config-invalid-address.toml"}
+	main()
+	assert.True(t, true)
+}
+
 //nolint:paralleltest // disabled
 func TestValidateConfigValid(t *testing.T) {
 	os.Args = []string{"cmd", "validate-config", "--config", "../assets/config-valid.toml"}
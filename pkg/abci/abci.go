@@ -0,0 +1,114 @@
+package abci
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/http"
+	"main/pkg/proto"
+	"main/pkg/types"
+	nethttp "net/http"
+	"net/url"
+	"strconv"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+// Client queries the node via CometBFT RPC abci_query, with protobuf-encoded
+// requests and responses, the same way gRPC queries are done internally.
+type Client struct {
+	Client   *http.Client
+	Endpoint string
+	PageSize uint64
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+
+	LastQueryHeight map[string]int64
+	Mutex           sync.Mutex
+}
+
+func NewClient(chain config.Chain, logger zerolog.Logger, tracer trace.Tracer) *Client {
+	return &Client{
+		Client:          http.NewClient(logger, chain.Name, tracer),
+		Endpoint:        chain.RPCEndpoint,
+		PageSize:        chain.PageSize,
+		Logger:          logger.With().Str("component", "abci").Str("chain", chain.Name).Logger(),
+		Tracer:          tracer,
+		LastQueryHeight: make(map[string]int64),
+	}
+}
+
+func (c *Client) GetWalletBalances(address string, ctx context.Context) (*types.BalanceResponse, types.QueryInfo, error) {
+	return proto.GetAllBalances(c.Query, address, c.PageSize, ctx)
+}
+
+// Query does an abci_query for the gRPC method path, checking that the returned
+// block height is not older than the one returned for the same address previously.
+func (c *Client) Query(
+	method string,
+	address string,
+	request proto.Message,
+	response proto.Message,
+	ctx context.Context,
+) (types.QueryInfo, error) {
+	requestBytes, err := request.Marshal()
+	if err != nil {
+		return types.QueryInfo{}, err
+	}
+
+	queryURL := fmt.Sprintf(
+		"%s/abci_query?path=%s&data=0x%s",
+		c.Endpoint,
+		url.QueryEscape(strconv.Quote(method)),
+		hex.EncodeToString(requestBytes),
+	)
+
+	var abciResponse *types.ABCIQueryResponse
+	queryInfo, _, err := c.Client.Get(queryURL, &abciResponse, types.HTTPPredicateAlwaysPass(), ctx)
+	queryInfo.Endpoint = c.Endpoint
+
+	if err != nil {
+		return queryInfo, err
+	}
+
+	result := abciResponse.Result.Response
+	if result.Code != 0 {
+		queryInfo.Success = false
+		return queryInfo, fmt.Errorf("abci_query returned error code %d: %s", result.Code, result.Log)
+	}
+
+	c.Mutex.Lock()
+	lastHeight := c.LastQueryHeight[address]
+	c.Mutex.Unlock()
+
+	// abci_query returns the height in the response body, so converting it to the header
+	// LCD returns it in to use the same predicate
+	header := nethttp.Header{}
+	header.Set(constants.HeaderBlockHeight, strconv.FormatInt(result.Height, 10))
+
+	predicate := types.HTTPPredicateCheckHeightAfter(lastHeight)
+	if err := predicate(&nethttp.Response{Header: header}); err != nil {
+		queryInfo.Success = false
+		return queryInfo, err
+	}
+
+	if err := response.Unmarshal(result.Value); err != nil {
+		queryInfo.Success = false
+		return queryInfo, err
+	}
+
+	queryInfo.Height = result.Height
+
+	c.Mutex.Lock()
+	if result.Height > c.LastQueryHeight[address] {
+		c.LastQueryHeight[address] = result.Height
+	}
+	c.Mutex.Unlock()
+
+	return queryInfo, nil
+}
@@ -0,0 +1,102 @@
+package abci
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	"main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const balancesQueryURL = "https://rpc.example.com/abci_query" +
+	"?path=%22%2Fcosmos.bank.v1beta1.Query%2FAllBalances%22&data=0x0a0761646472657373"
+
+func getTestClient() *Client {
+	return NewClient(
+		config.Chain{Name: "chain", RPCEndpoint: "https://rpc.example.com"},
+		*loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestABCIGetWalletBalancesQueryFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", balancesQueryURL, httpmock.NewErrorResponder(errors.New("custom error")))
+
+	balances, queryInfo, err := getTestClient().GetWalletBalances("address", context.Background())
+	require.Error(t, err)
+	assert.False(t, queryInfo.Success)
+	assert.Equal(t, "https://rpc.example.com", queryInfo.Endpoint)
+	assert.Nil(t, balances)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestABCIGetWalletBalancesErrorCode(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		balancesQueryURL,
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("abci-query-error.json")),
+	)
+
+	balances, queryInfo, err := getTestClient().GetWalletBalances("address", context.Background())
+	require.ErrorContains(t, err, "abci_query returned error code 22: invalid address")
+	assert.False(t, queryInfo.Success)
+	assert.Nil(t, balances)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestABCIGetWalletBalancesHeightRegression(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		balancesQueryURL,
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("abci-query-balances.json")),
+	)
+
+	client := getTestClient()
+	client.LastQueryHeight["address"] = 20000
+
+	balances, queryInfo, err := client.GetWalletBalances("address", context.Background())
+	require.ErrorContains(t, err, "previous height (20000) is bigger than the current height (12345)")
+	assert.False(t, queryInfo.Success)
+	assert.Nil(t, balances)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestABCIGetWalletBalancesOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		balancesQueryURL,
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("abci-query-balances.json")),
+	)
+
+	client := getTestClient()
+
+	balances, queryInfo, err := client.GetWalletBalances("address", context.Background())
+	require.NoError(t, err)
+	assert.True(t, queryInfo.Success)
+	require.Len(t, balances.Balances, 2)
+	assert.Equal(t, "uatom", balances.Balances[0].Denom)
+	assert.Equal(t, int64(123), balances.Balances[0].Amount.TruncateInt64())
+	assert.Equal(t, "ustake", balances.Balances[1].Denom)
+	assert.Equal(t, int64(4567), balances.Balances[1].Amount.TruncateInt64())
+	assert.Equal(t, int64(12345), client.LastQueryHeight["address"])
+	assert.Equal(t, int64(12345), queryInfo.Height)
+}
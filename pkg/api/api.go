@@ -0,0 +1,305 @@
+package api
+
+import (
+	"encoding/json"
+	"main/pkg/config"
+	"main/pkg/history"
+	queriersPkg "main/pkg/queriers"
+	"main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	AmountTypeBalance          = "balance"
+	AmountTypeSpendable        = "spendable"
+	AmountTypeDelegated        = "delegated"
+	AmountTypeUnbonding        = "unbonding"
+	AmountTypeRedelegating     = "redelegating"
+	AmountTypeRewards          = "rewards"
+	AmountTypeCommission       = "commission"
+	AmountTypeVestingOriginal  = "vesting_original"
+	AmountTypeVestingDelegated = "vesting_delegated"
+	AmountTypeVestingLocked    = "vesting_locked"
+)
+
+// WalletResponse is a wallet with all of its fetched amounts, for accounting,
+// where float64 metrics precision is not enough.
+type WalletResponse struct {
+	Chain     string           `json:"chain"`
+	Address   string           `json:"address"`
+	Name      string           `json:"name"`
+	Group     string           `json:"group"`
+	Success   bool             `json:"success"`
+	UpdatedAt *time.Time       `json:"updated_at,omitempty"`
+	Amounts   []AmountResponse `json:"amounts"`
+}
+
+// AmountResponse is a single wallet amount, with the exact amount in base units
+// (like "1234567" uatom) and in display units (like "1.234567" atom) as strings.
+type AmountResponse struct {
+	Type          string `json:"type"`
+	TokenType     string `json:"token_type,omitempty"`
+	Denom         string `json:"denom"`
+	BaseDenom     string `json:"base_denom"`
+	IBCPath       string `json:"ibc_path,omitempty"`
+	DisplayDenom  string `json:"display_denom"`
+	Exponent      int    `json:"exponent"`
+	Amount        string `json:"amount"`
+	DisplayAmount string `json:"display_amount,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// HistoryResponse is the wallet balances recorded over time, from the oldest to the newest.
+type HistoryResponse struct {
+	Chain   string                  `json:"chain"`
+	Address string                  `json:"address"`
+	Records []HistoryRecordResponse `json:"records"`
+}
+
+type HistoryRecordResponse struct {
+	Timestamp time.Time        `json:"timestamp"`
+	Height    int64            `json:"height"`
+	Amounts   []AmountResponse `json:"amounts"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+type API struct {
+	Config       *config.Config
+	State        *state.State
+	HistoryStore *history.Store
+	Logger       zerolog.Logger
+}
+
+// NewAPI creates the API, with the history store being nil if the history is disabled.
+func NewAPI(
+	appConfig *config.Config,
+	appState *state.State,
+	historyStore *history.Store,
+	logger zerolog.Logger,
+) *API {
+	return &API{
+		Config:       appConfig,
+		State:        appState,
+		HistoryStore: historyStore,
+		Logger:       logger.With().Str("component", "api").Logger(),
+	}
+}
+
+// Balances returns all the wallets amounts as JSON, optionally filtered by the chain query param.
+func (a *API) Balances(w http.ResponseWriter, r *http.Request) {
+	chainName := r.URL.Query().Get("chain")
+	response := []WalletResponse{}
+
+	for _, chain := range a.Config.Chains {
+		if chainName != "" && chain.Name != chainName {
+			continue
+		}
+
+		for _, wallet := range chain.Wallets {
+			entry, found := a.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
+				continue
+			}
+
+			response = append(response, a.getWalletResponse(chain, wallet, entry))
+		}
+	}
+
+	a.writeJSON(w, response)
+}
+
+// History returns the wallet balances history, requiring the chain and address query params,
+// and optionally limited by the from and to ones, as RFC3339 timestamps.
+func (a *API) History(w http.ResponseWriter, r *http.Request) {
+	if a.HistoryStore == nil {
+		a.writeError(w, http.StatusNotFound, "history is disabled")
+		return
+	}
+
+	query := r.URL.Query()
+	chainName := query.Get("chain")
+	address := query.Get("address")
+
+	if chainName == "" || address == "" {
+		a.writeError(w, http.StatusBadRequest, "chain and address should be provided")
+		return
+	}
+
+	chain, found := a.Config.FindChainByName(chainName)
+	if !found {
+		a.writeError(w, http.StatusNotFound, "chain is not found: "+chainName)
+		return
+	}
+
+	from, err := parseTimeParam(query.Get("from"))
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "invalid from: "+err.Error())
+		return
+	}
+
+	to, err := parseTimeParam(query.Get("to"))
+	if err != nil {
+		a.writeError(w, http.StatusBadRequest, "invalid to: "+err.Error())
+		return
+	}
+
+	records, err := a.HistoryStore.Query(chainName, address, from, to)
+	if err != nil {
+		a.Logger.Error().Err(err).Str("chain", chainName).Str("address", address).Msg("Could not query history")
+		a.writeError(w, http.StatusInternalServerError, "could not query history")
+		return
+	}
+
+	response := HistoryResponse{
+		Chain:   chainName,
+		Address: address,
+		Records: make([]HistoryRecordResponse, len(records)),
+	}
+
+	for index, record := range records {
+		response.Records[index] = a.getHistoryRecordResponse(*chain, record)
+	}
+
+	a.writeJSON(w, response)
+}
+
+func (a *API) getHistoryRecordResponse(chain config.Chain, record history.Record) HistoryRecordResponse {
+	recordResponse := HistoryRecordResponse{
+		Timestamp: record.Timestamp,
+		Height:    record.Height,
+		Amounts:   make([]AmountResponse, len(record.Balances)),
+	}
+
+	for index, recordBalance := range record.Balances {
+		balance, err := recordBalance.ToBalance()
+		if err != nil {
+			recordResponse.Amounts[index] = AmountResponse{
+				Type:   AmountTypeBalance,
+				Denom:  recordBalance.Denom,
+				Amount: recordBalance.Amount,
+				Error:  err.Error(),
+			}
+			continue
+		}
+
+		recordResponse.Amounts[index] = a.getAmountResponse(chain, AmountTypeBalance, types.TokenTypeNative, balance)
+	}
+
+	return recordResponse
+}
+
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func (a *API) getWalletResponse(
+	chain config.Chain,
+	wallet config.Wallet,
+	entry types.WalletBalanceEntry,
+) WalletResponse {
+	walletResponse := WalletResponse{
+		Chain:   chain.Name,
+		Address: wallet.Address,
+		Name:    wallet.Name,
+		Group:   wallet.Group,
+		Success: entry.Success,
+		Amounts: []AmountResponse{},
+	}
+
+	if !entry.UpdatedAt.IsZero() {
+		updatedAt := entry.UpdatedAt
+		walletResponse.UpdatedAt = &updatedAt
+	}
+
+	addAmounts := func(amountType string, tokenType string, balances types.Balances) {
+		for _, balance := range balances {
+			walletResponse.Amounts = append(
+				walletResponse.Amounts,
+				a.getAmountResponse(chain, amountType, tokenType, balance),
+			)
+		}
+	}
+
+	addAmounts(AmountTypeBalance, types.TokenTypeNative, entry.Balances)
+	addAmounts(AmountTypeBalance, types.TokenTypeCW20, entry.CW20Balances)
+	addAmounts(AmountTypeBalance, types.TokenTypeEVM, entry.EVMBalances)
+	addAmounts(AmountTypeBalance, types.TokenTypeERC20, entry.ERC20Balances)
+	addAmounts(AmountTypeSpendable, "", entry.Spendable)
+	addAmounts(AmountTypeDelegated, "", entry.Delegations)
+	addAmounts(AmountTypeUnbonding, "", entry.Unbondings)
+	addAmounts(AmountTypeRedelegating, "", entry.Redelegations)
+	addAmounts(AmountTypeRewards, "", entry.Rewards)
+	addAmounts(AmountTypeCommission, "", entry.Commission)
+
+	if entry.Account != nil && entry.Account.IsVesting() {
+		addAmounts(AmountTypeVestingOriginal, "", entry.Account.BaseVestingAccount.OriginalVesting)
+		addAmounts(AmountTypeVestingDelegated, "", entry.Account.BaseVestingAccount.DelegatedVesting)
+		addAmounts(AmountTypeVestingLocked, "", entry.Account.GetLockedCoins(time.Now()))
+	}
+
+	return walletResponse
+}
+
+func (a *API) getAmountResponse(
+	chain config.Chain,
+	amountType string,
+	tokenType string,
+	balance types.Balance,
+) AmountResponse {
+	denom := queriersPkg.ResolveTokenDenom(a.Config, a.State, chain, balance.Denom, tokenType)
+
+	amountResponse := AmountResponse{
+		Type:         amountType,
+		TokenType:    tokenType,
+		Denom:        balance.Denom,
+		BaseDenom:    denom.BaseDenom,
+		IBCPath:      denom.IBCPath,
+		DisplayDenom: denom.GetName(),
+		Exponent:     denom.GetExponent(),
+	}
+
+	if balance.Amount.IsNil() {
+		amountResponse.Error = "amount is nil"
+		return amountResponse
+	}
+
+	amountResponse.Amount = utils.FormatAmount(balance.Amount)
+
+	displayAmount, err := denom.GetDisplayAmount(balance)
+	if err != nil {
+		amountResponse.Error = err.Error()
+		return amountResponse
+	}
+
+	amountResponse.DisplayAmount = utils.FormatAmount(displayAmount)
+	return amountResponse
+}
+
+func (a *API) writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(ErrorResponse{Error: message}); err != nil {
+		a.Logger.Error().Err(err).Msg("Could not write API response")
+	}
+}
+
+func (a *API) writeJSON(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		a.Logger.Error().Err(err).Msg("Could not write API response")
+	}
+}
@@ -0,0 +1,230 @@
+package api
+
+import (
+	"encoding/json"
+	configPkg "main/pkg/config"
+	"main/pkg/history"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getBalances(t *testing.T, api *API, url string) []WalletResponse {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	api.Balances(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	var response []WalletResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	return response
+}
+
+func TestAPIBalances(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{
+		{
+			Name: "evmos",
+			Wallets: []configPkg.Wallet{
+				{Address: "evmos1", Name: "name", Group: "group"},
+				{Address: "evmos2", Name: "not-fetched"},
+			},
+			Denoms: []configPkg.DenomInfo{
+				{Denom: "aevmos", DisplayDenom: "evmos", DenomExponent: null.IntFrom(18)},
+			},
+		},
+		{
+			Name:    "cosmos",
+			Wallets: []configPkg.Wallet{{Address: "cosmos1"}},
+		},
+	}}
+
+	updatedAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:   "evmos",
+		Success: true,
+		Wallet:  config.Chains[0].Wallets[0],
+		Balances: types.Balances{
+			{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("123456789123456789123456789")},
+			{Denom: "ustake"},
+		},
+		Rewards: types.Balances{
+			{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("1500000000000000000.25")},
+		},
+		UpdatedAt: updatedAt,
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:  "cosmos",
+		Wallet: config.Chains[1].Wallets[0],
+	})
+
+	api := NewAPI(config, state, nil, *loggerPkg.GetNopLogger())
+
+	response := getBalances(t, api, "/api/balances")
+	require.Len(t, response, 2)
+
+	assert.Equal(t, WalletResponse{
+		Chain:     "evmos",
+		Address:   "evmos1",
+		Name:      "name",
+		Group:     "group",
+		Success:   true,
+		UpdatedAt: &updatedAt,
+		Amounts: []AmountResponse{
+			{
+				Type:          AmountTypeBalance,
+				TokenType:     types.TokenTypeNative,
+				Denom:         "aevmos",
+				BaseDenom:     "aevmos",
+				DisplayDenom:  "evmos",
+				Exponent:      18,
+				Amount:        "123456789123456789123456789",
+				DisplayAmount: "123456789.123456789123456789",
+			},
+			{
+				Type:         AmountTypeBalance,
+				TokenType:    types.TokenTypeNative,
+				Denom:        "ustake",
+				BaseDenom:    "ustake",
+				DisplayDenom: "ustake",
+				Error:        "amount is nil",
+			},
+			{
+				Type:          AmountTypeRewards,
+				Denom:         "aevmos",
+				BaseDenom:     "aevmos",
+				DisplayDenom:  "evmos",
+				Exponent:      18,
+				Amount:        "1500000000000000000.25",
+				DisplayAmount: "1.5",
+			},
+		},
+	}, response[0])
+
+	assert.Equal(t, WalletResponse{
+		Chain:   "cosmos",
+		Address: "cosmos1",
+		Amounts: []AmountResponse{},
+	}, response[1])
+
+	filtered := getBalances(t, api, "/api/balances?chain=cosmos")
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "cosmos", filtered[0].Chain)
+}
+
+func getHistory(t *testing.T, api *API, url string, expectedCode int) []byte {
+	t.Helper()
+
+	recorder := httptest.NewRecorder()
+	api.History(recorder, httptest.NewRequest(http.MethodGet, url, nil))
+	require.Equal(t, expectedCode, recorder.Code)
+	assert.Equal(t, "application/json", recorder.Header().Get("Content-Type"))
+
+	return recorder.Body.Bytes()
+}
+
+func TestAPIHistory(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:   "cosmos",
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	store, err := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for index := 0; index < 3; index++ {
+		require.NoError(t, store.Record("cosmos", "cosmos1", history.Record{
+			Timestamp: start.Add(time.Duration(index) * time.Hour),
+			Height:    int64(100 + index),
+			Balances:  []history.Balance{{Denom: "uatom", Amount: "1500000"}},
+		}))
+	}
+
+	api := NewAPI(config, statePkg.NewState(), store, *loggerPkg.GetNopLogger())
+
+	var response HistoryResponse
+	require.NoError(t, json.Unmarshal(getHistory(t, api, "/api/history?chain=cosmos&address=cosmos1", http.StatusOK), &response))
+	assert.Equal(t, "cosmos", response.Chain)
+	assert.Equal(t, "cosmos1", response.Address)
+	require.Len(t, response.Records, 3)
+	assert.True(t, start.Equal(response.Records[0].Timestamp))
+	assert.Equal(t, int64(100), response.Records[0].Height)
+	assert.Equal(t, []AmountResponse{{
+		Type:          AmountTypeBalance,
+		TokenType:     types.TokenTypeNative,
+		Denom:         "uatom",
+		BaseDenom:     "uatom",
+		DisplayDenom:  "atom",
+		Exponent:      6,
+		Amount:        "1500000",
+		DisplayAmount: "1.5",
+	}}, response.Records[0].Amounts)
+
+	body := getHistory(
+		t,
+		api,
+		"/api/history?chain=cosmos&address=cosmos1&from=2024-01-01T01:00:00Z&to=2024-01-01T01:30:00Z",
+		http.StatusOK,
+	)
+	require.NoError(t, json.Unmarshal(body, &response))
+	require.Len(t, response.Records, 1)
+	assert.Equal(t, int64(101), response.Records[0].Height)
+
+	require.NoError(t, json.Unmarshal(getHistory(t, api, "/api/history?chain=cosmos&address=cosmos2", http.StatusOK), &response))
+	assert.Empty(t, response.Records)
+}
+
+func TestAPIHistoryErrors(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{Name: "cosmos"}}}
+
+	store, err := history.NewStore(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+	defer store.Close()
+
+	api := NewAPI(config, statePkg.NewState(), store, *loggerPkg.GetNopLogger())
+
+	testCases := []struct {
+		url  string
+		code int
+		err  string
+	}{
+		{"/api/history?chain=cosmos", http.StatusBadRequest, "chain and address should be provided"},
+		{"/api/history?chain=osmosis&address=osmo1", http.StatusNotFound, "chain is not found: osmosis"},
+		{"/api/history?chain=cosmos&address=cosmos1&from=yesterday", http.StatusBadRequest, "invalid from"},
+		{"/api/history?chain=cosmos&address=cosmos1&to=2024-01-01", http.StatusBadRequest, "invalid to"},
+	}
+
+	for _, testCase := range testCases {
+		var response ErrorResponse
+		require.NoError(t, json.Unmarshal(getHistory(t, api, testCase.url, testCase.code), &response))
+		assert.Contains(t, response.Error, testCase.err, testCase.url)
+	}
+
+	disabledAPI := NewAPI(config, statePkg.NewState(), nil, *loggerPkg.GetNopLogger())
+
+	var response ErrorResponse
+	require.NoError(t, json.Unmarshal(getHistory(t, disabledAPI, "/api/history?chain=cosmos&address=cosmos1", http.StatusNotFound), &response))
+	assert.Equal(t, "history is disabled", response.Error)
+}
@@ -2,11 +2,16 @@ package pkg
 
 import (
 	"context"
-	coingeckoPkg "main/pkg/coingecko"
+	apiPkg "main/pkg/api"
 	"main/pkg/config"
 	"main/pkg/fs"
+	historyPkg "main/pkg/history"
 	"main/pkg/logger"
+	notifierPkg "main/pkg/notifier"
+	pricesPkg "main/pkg/prices"
 	queriersPkg "main/pkg/queriers"
+	schedulerPkg "main/pkg/scheduler"
+	statePkg "main/pkg/state"
 	"main/pkg/tracing"
 	"main/pkg/types"
 	"net/http"
@@ -25,11 +30,14 @@ import (
 )
 
 type App struct {
-	Config   *config.Config
-	Logger   zerolog.Logger
-	Queriers []types.Querier
-	Server   *http.Server
-	Tracer   trace.Tracer
+	API       *apiPkg.API
+	Config    *config.Config
+	History   *historyPkg.Store
+	Logger    zerolog.Logger
+	Queriers  []types.Querier
+	Scheduler *schedulerPkg.Scheduler
+	Server    *http.Server
+	Tracer    trace.Tracer
 }
 
 func NewApp(filesystem fs.FS, configPath string, version string) *App {
@@ -44,22 +52,52 @@ func NewApp(filesystem fs.FS, configPath string, version string) *App {
 
 	tracer := tracing.InitTracer(appConfig.TracingConfig, version)
 	log := logger.GetLogger(appConfig.LogConfig)
-	coingecko := coingeckoPkg.NewCoingecko(appConfig, log, tracer)
+
+	for _, warning := range appConfig.Warnings {
+		log.Warn().Msg(warning)
+	}
+
+	priceProvider := pricesPkg.NewPriceProvider(appConfig, log, tracer)
+	state := statePkg.NewState()
+	scheduler := schedulerPkg.NewScheduler(appConfig, state, log, tracer)
+
+	if appConfig.NotifierConfig.Enabled {
+		scheduler.AddListener(notifierPkg.NewNotifier(appConfig, state, log, tracer))
+	}
+
+	var historyStore *historyPkg.Store
+	if appConfig.HistoryConfig.Enabled {
+		historyStore, err = historyPkg.NewStore(appConfig.HistoryConfig.Path)
+		if err != nil {
+			log.Panic().Err(err).Str("path", appConfig.HistoryConfig.Path).Msg("Could not open history storage")
+		}
+
+		scheduler.AddListener(historyPkg.NewRecorder(appConfig, state, historyStore, log, tracer))
+	}
 
 	queriers := []types.Querier{
-		queriersPkg.NewPriceQuerier(appConfig, coingecko, tracer),
-		queriersPkg.NewBalanceQuerier(appConfig, log, tracer),
+		queriersPkg.NewPriceQuerier(appConfig, priceProvider, state, log, tracer),
+		queriersPkg.NewBalanceQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewStakingQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewRewardsQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewVestingQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewThresholdsQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewBurnRateQuerier(appConfig, state, log, tracer),
+		queriersPkg.NewEndpointsQuerier(appConfig, state, tracer),
 		queriersPkg.NewUptimeQuerier(tracer),
 	}
 
 	server := &http.Server{Addr: appConfig.ListenAddress, Handler: nil}
 
 	return &App{
-		Config:   appConfig,
-		Logger:   log,
-		Queriers: queriers,
-		Tracer:   tracer,
-		Server:   server,
+		API:       apiPkg.NewAPI(appConfig, state, historyStore, log),
+		Config:    appConfig,
+		History:   historyStore,
+		Logger:    log,
+		Queriers:  queriers,
+		Scheduler: scheduler,
+		Tracer:    tracer,
+		Server:    server,
 	}
 }
 
@@ -68,8 +106,16 @@ func (a *App) Start() {
 	handler := http.NewServeMux()
 	handler.Handle("/metrics", otelHandler)
 	handler.HandleFunc("/healthcheck", a.Healthcheck)
+
+	if a.Config.APIConfig.Enabled {
+		handler.HandleFunc("/api/balances", a.API.Balances)
+		handler.HandleFunc("/api/history", a.API.History)
+	}
+
 	a.Server.Handler = handler
 
+	a.Scheduler.Start()
+
 	a.Logger.Info().Str("addr", a.Config.ListenAddress).Msg("Listening")
 
 	err := a.Server.ListenAndServe()
@@ -80,9 +126,17 @@ func (a *App) Start() {
 
 func (a *App) Stop() {
 	a.Logger.Info().Str("addr", a.Config.ListenAddress).Msg("Shutting down server...")
+	a.Scheduler.Stop()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	_ = a.Server.Shutdown(ctx)
+
+	if a.History != nil {
+		if err := a.History.Close(); err != nil {
+			a.Logger.Error().Err(err).Msg("Could not close history storage")
+		}
+	}
 }
 
 func (a *App) Handler(w http.ResponseWriter, r *http.Request) {
@@ -55,6 +55,19 @@ func TestAppFailToStart(t *testing.T) {
 	app.Start()
 }
 
+//nolint:paralleltest // disabled
+func TestAppInvalidHistoryPath(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			require.Fail(t, "Expected to have a panic here!")
+		}
+	}()
+
+	filesystem := &fs.TestFS{}
+
+	NewApp(filesystem, "config-invalid-history-path.toml", "1.2.3")
+}
+
 //nolint:paralleltest // disabled
 func TestAppStopOperation(t *testing.T) {
 	filesystem := &fs.TestFS{}
@@ -68,19 +81,6 @@ func TestAppStopOperation(t *testing.T) {
 func TestAppLoadConfigOk(t *testing.T) {
 	filesystem := &fs.TestFS{}
 
-	app := NewApp(filesystem, "config-valid.toml", "1.2.3")
-	go app.Start()
-
-	for {
-		request, err := http.Get("http://localhost:9550/healthcheck")
-		_ = request.Body.Close()
-		if err == nil {
-			break
-		}
-
-		time.Sleep(time.Millisecond * 100)
-	}
-
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
 
@@ -99,6 +99,19 @@ func TestAppLoadConfigOk(t *testing.T) {
 	httpmock.RegisterResponder("GET", "http://localhost:9550/healthcheck", httpmock.InitialTransport.RoundTrip)
 	httpmock.RegisterResponder("GET", "http://localhost:9550/metrics", httpmock.InitialTransport.RoundTrip)
 
+	app := NewApp(filesystem, "config-valid.toml", "1.2.3")
+	go app.Start()
+
+	for {
+		request, err := http.Get("http://localhost:9550/healthcheck")
+		if err == nil {
+			_ = request.Body.Close()
+			break
+		}
+
+		time.Sleep(time.Millisecond * 100)
+	}
+
 	response, err := http.Get("http://localhost:9550/metrics")
 	require.NoError(t, err)
 	require.NotEmpty(t, response)
@@ -0,0 +1,94 @@
+package bech32
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/btcutil/bech32"
+	"github.com/decred/dcrd/crypto/ripemd160"
+)
+
+// MaxLength is the max address length, the same as cosmos-sdk uses,
+// as some addresses (like module or ICA ones) are longer than the 90 chars BIP-173 allows.
+const MaxLength = 1023
+
+// Decode decodes a bech32 string, returning its human-readable part (the address prefix)
+// and the data converted to 8-bit bytes.
+func Decode(address string) (string, []byte, error) {
+	hrp, data, err := bech32.Decode(address, MaxLength)
+	if err != nil {
+		return "", nil, err
+	}
+
+	converted, err := bech32.ConvertBits(data, 5, 8, false)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return hrp, converted, nil
+}
+
+// Encode encodes 8-bit data as a bech32 string with the given prefix.
+func Encode(hrp string, data []byte) (string, error) {
+	return bech32.EncodeFromBase256(strings.ToLower(hrp), data)
+}
+
+// IsHexAddress returns whether the address is an EVM 0x-prefixed hex address.
+func IsHexAddress(address string) bool {
+	if len(address) != 42 || !strings.HasPrefix(address, "0x") {
+		return false
+	}
+
+	_, err := hex.DecodeString(address[2:])
+	return err == nil
+}
+
+// HexToBech32 converts an EVM 0x-prefixed hex address to a bech32 one with the given prefix.
+func HexToBech32(address string, prefix string) (string, error) {
+	if !IsHexAddress(address) {
+		return "", fmt.Errorf("invalid hex address: %s", address)
+	}
+
+	bytes, err := hex.DecodeString(address[2:])
+	if err != nil {
+		return "", err
+	}
+
+	return Encode(prefix, bytes)
+}
+
+// Bech32ToHex converts a bech32 address to an EVM 0x-prefixed hex one.
+// Only works for 20-byte addresses, as EVM addresses are 20 bytes long.
+func Bech32ToHex(address string) (string, error) {
+	_, bytes, err := Decode(address)
+	if err != nil {
+		return "", err
+	}
+
+	if len(bytes) != 20 {
+		return "", fmt.Errorf("expected 20 bytes address, got %d bytes", len(bytes))
+	}
+
+	return "0x" + hex.EncodeToString(bytes), nil
+}
+
+// PubKeyToAddress returns the address bytes of a compressed secp256k1 public key
+// the way cosmos-sdk derives them, as RIPEMD160(SHA256(pubkey)).
+// This is not how EVM (ethsecp256k1) addresses are derived.
+func PubKeyToAddress(pubKey []byte) ([]byte, error) {
+	if len(pubKey) != 33 {
+		return nil, fmt.Errorf("expected 33 bytes compressed pubkey, got %d bytes", len(pubKey))
+	}
+
+	if pubKey[0] != 0x02 && pubKey[0] != 0x03 {
+		return nil, fmt.Errorf("invalid compressed pubkey prefix: 0x%02x", pubKey[0])
+	}
+
+	sha := sha256.Sum256(pubKey)
+	hasher := ripemd160.New()
+	hasher.Write(sha[:])
+
+	return hasher.Sum(nil), nil
+}
@@ -0,0 +1,114 @@
+package bech32
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeValid(t *testing.T) {
+	t.Parallel()
+
+	prefix, bytes, err := Decode("cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7")
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos", prefix)
+	assert.Len(t, bytes, 20)
+
+	prefix, _, err = Decode("COSMOS18G57FLMD85H8ARRM9EDK5Z323SHX7XSTA69VW7")
+	require.NoError(t, err)
+	assert.Equal(t, "cosmos", prefix)
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := Decode("cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw8")
+	require.ErrorContains(t, err, "invalid checksum")
+
+	_, _, err = Decode("Cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7")
+	require.ErrorContains(t, err, "not all lowercase or all uppercase")
+
+	_, _, err = Decode("cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vwb")
+	require.ErrorContains(t, err, "invalid character not part of charset")
+
+	_, _, err = Decode("18g57flmd85h8arrm9edk5z323shx7xsta69vw7")
+	require.ErrorContains(t, err, "invalid separator index")
+
+	_, _, err = Decode("cosmos1abc")
+	require.ErrorContains(t, err, "invalid separator index")
+
+	_, _, err = Decode("cosmos1" + strings.Repeat("q", MaxLength))
+	require.ErrorContains(t, err, "invalid bech32 string length")
+}
+
+func TestEncode(t *testing.T) {
+	t.Parallel()
+
+	_, bytes, err := Decode("cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7")
+	require.NoError(t, err)
+
+	address, err := Encode("osmo", bytes)
+	require.NoError(t, err)
+	assert.Equal(t, "osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucv", address)
+}
+
+func TestIsHexAddress(t *testing.T) {
+	t.Parallel()
+
+	assert.True(t, IsHexAddress("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"))
+	assert.True(t, IsHexAddress("0x3A29E4FF6D3D2E7E8C7B2E5B6A0A2A8C2E6F1A0B"))
+	assert.False(t, IsHexAddress("3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"))
+	assert.False(t, IsHexAddress("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0"))
+	assert.False(t, IsHexAddress("0xzz29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"))
+	assert.False(t, IsHexAddress("cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"))
+}
+
+func TestHexToBech32(t *testing.T) {
+	t.Parallel()
+
+	address, err := HexToBech32("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b", "evmos")
+	require.NoError(t, err)
+	assert.Equal(t, "evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k", address)
+
+	_, err = HexToBech32("evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k", "evmos")
+	require.ErrorContains(t, err, "invalid hex address")
+}
+
+func TestBech32ToHex(t *testing.T) {
+	t.Parallel()
+
+	address, err := Bech32ToHex("evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k")
+	require.NoError(t, err)
+	assert.Equal(t, "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b", address)
+
+	_, err = Bech32ToHex("invalid")
+	require.Error(t, err)
+
+	longAddress, err := Encode("cosmos", make([]byte, 32))
+	require.NoError(t, err)
+
+	_, err = Bech32ToHex(longAddress)
+	require.ErrorContains(t, err, "expected 20 bytes address")
+}
+
+func TestPubKeyToAddress(t *testing.T) {
+	t.Parallel()
+
+	// secp256k1 generator point, the pubkey of the private key 1, with a well-known hash
+	pubKey, err := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	require.NoError(t, err)
+
+	address, err := PubKeyToAddress(pubKey)
+	require.NoError(t, err)
+	assert.Equal(t, "751e76e8199196d454941c45d1b3a323f1433bd6", hex.EncodeToString(address))
+
+	_, err = PubKeyToAddress(pubKey[1:])
+	require.ErrorContains(t, err, "expected 33 bytes compressed pubkey")
+
+	invalidPubKey := append([]byte{0x04}, pubKey[1:]...)
+	_, err = PubKeyToAddress(invalidPubKey)
+	require.ErrorContains(t, err, "invalid compressed pubkey prefix: 0x04")
+}
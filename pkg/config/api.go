@@ -0,0 +1,5 @@
+package config
+
+type APIConfig struct {
+	Enabled bool `default:"false" toml:"enabled"`
+}
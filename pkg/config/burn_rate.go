@@ -0,0 +1,25 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// BurnRateConfig is the wallets spend rate estimation config, keeping a rolling window
+// of the wallets balances to forecast when they go below their thresholds.
+type BurnRateConfig struct {
+	Enabled bool          `default:"false" toml:"enabled"`
+	Window  time.Duration `default:"24h"   toml:"window"`
+}
+
+func (c BurnRateConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Window <= 0 {
+		return errors.New("window should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurnRateConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, BurnRateConfig{}.Validate())
+	require.NoError(t, BurnRateConfig{Enabled: true, Window: time.Hour}.Validate())
+	require.ErrorContains(t, BurnRateConfig{Enabled: true}.Validate(), "window should be positive")
+	require.ErrorContains(t, BurnRateConfig{Enabled: true, Window: -time.Hour}.Validate(), "window should be positive")
+}
+
+func TestLoadBurnRateConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{}
+	_, err := toml.Decode(`
+[burn-rate]
+enabled = true
+`, config)
+	require.NoError(t, err)
+	require.NoError(t, defaults.Set(config))
+
+	assert.True(t, config.BurnRateConfig.Enabled)
+	assert.Equal(t, 24*time.Hour, config.BurnRateConfig.Window)
+	require.NoError(t, config.BurnRateConfig.Validate())
+}
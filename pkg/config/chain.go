@@ -3,13 +3,58 @@ package config
 import (
 	"errors"
 	"fmt"
+	"slices"
+	"time"
+
+	"github.com/guregu/null/v5"
+)
+
+const (
+	BalancesTransportLCD  = "lcd"
+	BalancesTransportGRPC = "grpc"
+	BalancesTransportABCI = "abci"
 )
 
+// DefaultEVMNativeExponent is used for the native EVM balance if the exponent of its denom
+// is neither set in the config nor returned in the chain denoms metadata, as eth_getBalance
+// returns the balance in wei, with 18 decimals.
+const DefaultEVMNativeExponent = 18
+
 type Chain struct {
-	Name        string      `toml:"name"`
-	LCDEndpoint string      `toml:"lcd-endpoint"`
-	Denoms      []DenomInfo `toml:"denoms"`
-	Wallets     []Wallet    `toml:"wallets"`
+	Name          string        `toml:"name"`
+	LCDEndpoint   string        `toml:"lcd-endpoint"`
+	LCDEndpoints  []string      `toml:"lcd-endpoints"`
+	MaxBlockLag   int64         `default:"10"          toml:"max-block-lag"`
+	GRPCEndpoint  string        `toml:"grpc-endpoint"`
+	GRPCTLS       bool          `toml:"grpc-tls"`
+	RPCEndpoint   string        `toml:"rpc-endpoint"`
+	QueryInterval time.Duration `default:"30s"         toml:"query-interval"`
+	PageSize      uint64        `default:"100"         toml:"page-size"`
+	Bech32Prefix  string        `toml:"bech32-prefix"`
+	CoinType      int64         `default:"118"         toml:"coin-type"`
+	Denoms        []DenomInfo   `toml:"denoms"`
+	CW20Tokens    []CW20Token   `toml:"cw20"`
+	ERC20Tokens   []ERC20Token  `toml:"erc20"`
+	Wallets       []Wallet      `toml:"wallets"`
+
+	ChainRegistryName             string        `toml:"chain-registry-name"`
+	BalancesTransport             string        `default:"lcd" toml:"balances-transport"`
+	EVMRPCEndpoint                string        `toml:"evm-rpc-endpoint"`
+	EVMNativeDenom                string        `toml:"evm-native-denom"`
+	DenomsMetadataRefreshInterval time.Duration `default:"1h"  toml:"denoms-metadata-refresh-interval"`
+
+	QueryDelegations          null.Bool `default:"false" toml:"query-delegations"`
+	QueryUnbondingDelegations null.Bool `default:"false" toml:"query-unbonding-delegations"`
+	QueryRedelegations        null.Bool `default:"false" toml:"query-redelegations"`
+	QueryRewards              null.Bool `default:"false" toml:"query-rewards"`
+	QueryVesting              null.Bool `default:"false" toml:"query-vesting"`
+	ResolveIBCDenoms          null.Bool `default:"true"  toml:"resolve-ibc-denoms"`
+	DiscoverDenomsMetadata    null.Bool `default:"true"  toml:"discover-denoms-metadata"`
+
+	// RegistryDenoms are the chain-registry assets that are not in Denoms, keyed by denom.
+	// They are only used to resolve the denoms the wallets have, and are not added to Denoms,
+	// as asset lists can have hundreds of them and each denom in Denoms gets its price fetched.
+	RegistryDenoms map[string]DenomInfo `toml:"-"`
 }
 
 func (c *Chain) Validate() error {
@@ -17,29 +62,211 @@ func (c *Chain) Validate() error {
 		return errors.New("empty chain name")
 	}
 
-	if c.LCDEndpoint == "" {
-		return errors.New("no LCD endpoint provided")
+	switch c.BalancesTransport {
+	case "", BalancesTransportLCD:
+		if len(c.GetLCDEndpoints()) == 0 {
+			return errors.New("no LCD endpoint provided")
+		}
+	case BalancesTransportGRPC:
+		if c.GRPCEndpoint == "" {
+			return errors.New("no gRPC endpoint provided")
+		}
+	case BalancesTransportABCI:
+		if c.RPCEndpoint == "" {
+			return errors.New("no RPC endpoint provided")
+		}
+	default:
+		return fmt.Errorf("unsupported balances transport: %s", c.BalancesTransport)
+	}
+
+	if c.MaxBlockLag < 0 {
+		return errors.New("max block lag cannot be negative")
+	}
+
+	if c.QueryInterval < 0 {
+		return errors.New("query interval cannot be negative")
+	}
+
+	if c.DenomsMetadataRefreshInterval < 0 {
+		return errors.New("denoms metadata refresh interval cannot be negative")
 	}
 
 	if len(c.Wallets) == 0 {
 		return errors.New("no wallets provided")
 	}
 
+	for index, denom := range c.Denoms {
+		if err := denom.Validate(); err != nil {
+			return fmt.Errorf("error in denom %d: %s", index, err)
+		}
+	}
+
+	for index, token := range c.CW20Tokens {
+		if err := token.Validate(); err != nil {
+			return fmt.Errorf("error in CW20 token %d: %s", index, err)
+		}
+	}
+
+	for index, token := range c.ERC20Tokens {
+		if err := token.Validate(); err != nil {
+			return fmt.Errorf("error in ERC-20 token %d: %s", index, err)
+		}
+	}
+
+	if c.EVMRPCEndpoint == "" && (c.EVMNativeDenom != "" || len(c.ERC20Tokens) > 0) {
+		return errors.New("no EVM JSON-RPC endpoint provided")
+	}
+
+	if len(c.GetLCDEndpoints()) == 0 && c.RequiresLCD() {
+		return errors.New("no LCD endpoint provided, which is required for CW20, staking, distribution and vesting queries")
+	}
+
+	// reporting all the invalid wallets at once, so they can all be fixed in one go
+	walletErrors := []error{}
+
 	for index, wallet := range c.Wallets {
 		if err := wallet.Validate(); err != nil {
-			return fmt.Errorf("error in wallet %d: %s", index, err)
+			walletErrors = append(walletErrors, fmt.Errorf("error in wallet %d: %s", index, err))
+			continue
+		}
+
+		if err := wallet.ValidateAddresses(c.Bech32Prefix); err != nil {
+			walletErrors = append(walletErrors, fmt.Errorf("error in wallet %d: %s", index, err))
+		}
+	}
+
+	return errors.Join(walletErrors...)
+}
+
+func (c *Chain) HasWallet(address string) bool {
+	for _, wallet := range c.Wallets {
+		if wallet.Address == address {
+			return true
 		}
 	}
 
-	return nil
+	return false
+}
+
+// GetLCDEndpoints returns all LCD endpoints of a chain, both the one from lcd-endpoint
+// (kept for backwards compatibility) and the ones from lcd-endpoints, without duplicates.
+func (c *Chain) GetLCDEndpoints() []string {
+	endpoints := []string{}
+
+	for _, endpoint := range append([]string{c.LCDEndpoint}, c.LCDEndpoints...) {
+		if endpoint == "" || slices.Contains(endpoints, endpoint) {
+			continue
+		}
+
+		endpoints = append(endpoints, endpoint)
+	}
+
+	return endpoints
 }
 
+// GetDenoms returns all the chain denoms, including CW20 and ERC-20 tokens.
+func (c *Chain) GetDenoms() []DenomInfo {
+	denoms := make([]DenomInfo, 0, len(c.Denoms)+len(c.CW20Tokens)+len(c.ERC20Tokens))
+	denoms = append(denoms, c.Denoms...)
+
+	for _, token := range c.CW20Tokens {
+		denoms = append(denoms, token.ToDenomInfo())
+	}
+
+	for _, token := range c.ERC20Tokens {
+		denoms = append(denoms, token.ToDenomInfo())
+	}
+
+	return denoms
+}
+
+// FindDenomByName returns the denom from the config or, if it's not there, from chain-registry.
 func (c *Chain) FindDenomByName(denom string) (*DenomInfo, bool) {
-	for _, denomIterated := range c.Denoms {
+	for _, denomIterated := range c.GetDenoms() {
 		if denomIterated.Denom == denom {
 			return &denomIterated, true
 		}
 	}
 
+	if registryDenom, found := c.RegistryDenoms[denom]; found {
+		return &registryDenom, true
+	}
+
 	return nil, false
 }
+
+// IsRegistryDenom returns whether the denom is only known from chain-registry.
+func (c *Chain) IsRegistryDenom(denom string) bool {
+	if _, found := c.RegistryDenoms[denom]; !found {
+		return false
+	}
+
+	for _, denomIterated := range c.GetDenoms() {
+		if denomIterated.Denom == denom {
+			return false
+		}
+	}
+
+	return true
+}
+
+// RequiresLCD returns whether any of the queries that are only done via LCD
+// (everything except bank balances) are enabled for the chain or any of its wallets.
+func (c *Chain) RequiresLCD() bool {
+	if len(c.CW20Tokens) > 0 {
+		return true
+	}
+
+	for _, wallet := range c.Wallets {
+		if c.IsDelegationsQueryEnabled(wallet) ||
+			c.IsUnbondingDelegationsQueryEnabled(wallet) ||
+			c.IsRedelegationsQueryEnabled(wallet) ||
+			c.IsRewardsQueryEnabled(wallet) ||
+			c.IsVestingQueryEnabled(wallet) ||
+			wallet.IsValidator() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *Chain) IsDelegationsQueryEnabled(wallet Wallet) bool {
+	if wallet.QueryDelegations.Valid {
+		return wallet.QueryDelegations.Bool
+	}
+
+	return c.QueryDelegations.Bool
+}
+
+func (c *Chain) IsUnbondingDelegationsQueryEnabled(wallet Wallet) bool {
+	if wallet.QueryUnbondingDelegations.Valid {
+		return wallet.QueryUnbondingDelegations.Bool
+	}
+
+	return c.QueryUnbondingDelegations.Bool
+}
+
+func (c *Chain) IsRedelegationsQueryEnabled(wallet Wallet) bool {
+	if wallet.QueryRedelegations.Valid {
+		return wallet.QueryRedelegations.Bool
+	}
+
+	return c.QueryRedelegations.Bool
+}
+
+func (c *Chain) IsRewardsQueryEnabled(wallet Wallet) bool {
+	if wallet.QueryRewards.Valid {
+		return wallet.QueryRewards.Bool
+	}
+
+	return c.QueryRewards.Bool
+}
+
+func (c *Chain) IsVestingQueryEnabled(wallet Wallet) bool {
+	if wallet.QueryVesting.Valid {
+		return wallet.QueryVesting.Bool
+	}
+
+	return c.QueryVesting.Bool
+}
@@ -0,0 +1,152 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"main/pkg/fs"
+	"path"
+
+	"github.com/guregu/null/v5"
+)
+
+// ChainRegistryChain is a chain.json file from the cosmos chain-registry,
+// with only the fields we need.
+type ChainRegistryChain struct {
+	ChainName    string `json:"chain_name"`
+	Bech32Prefix string `json:"bech32_prefix"`
+	Slip44       int64  `json:"slip44"`
+	APIs         struct {
+		REST []ChainRegistryAPI `json:"rest"`
+	} `json:"apis"`
+}
+
+type ChainRegistryAPI struct {
+	Address  string `json:"address"`
+	Provider string `json:"provider"`
+}
+
+// ChainRegistryAssetList is an assetlist.json file from the cosmos chain-registry.
+type ChainRegistryAssetList struct {
+	ChainName string               `json:"chain_name"`
+	Assets    []ChainRegistryAsset `json:"assets"`
+}
+
+type ChainRegistryAsset struct {
+	Base        string                   `json:"base"`
+	Display     string                   `json:"display"`
+	CoingeckoID string                   `json:"coingecko_id"`
+	DenomUnits  []ChainRegistryDenomUnit `json:"denom_units"`
+}
+
+type ChainRegistryDenomUnit struct {
+	Denom    string `json:"denom"`
+	Exponent int64  `json:"exponent"`
+}
+
+func (a ChainRegistryAsset) GetDisplayExponent() (int64, bool) {
+	for _, unit := range a.DenomUnits {
+		if unit.Denom == a.Display {
+			return unit.Exponent, true
+		}
+	}
+
+	return 0, false
+}
+
+// LoadFromChainRegistry fills the chain LCD endpoints, bech32 prefix, coin type and denoms
+// from the chain-registry files, if chain-registry-name is set for the chain.
+// Everything that is set explicitly in the config is kept as is.
+func (c *Chain) LoadFromChainRegistry(registryPath string, filesystem fs.FS) error {
+	if c.ChainRegistryName == "" {
+		return nil
+	}
+
+	if registryPath == "" {
+		return errors.New("chain-registry-path is not set")
+	}
+
+	var registryChain ChainRegistryChain
+	chainPath := path.Join(registryPath, c.ChainRegistryName, "chain.json")
+	if err := readJSONFile(chainPath, filesystem, &registryChain); err != nil {
+		return err
+	}
+
+	var assetList ChainRegistryAssetList
+	assetListPath := path.Join(registryPath, c.ChainRegistryName, "assetlist.json")
+	if err := readJSONFile(assetListPath, filesystem, &assetList); err != nil {
+		return err
+	}
+
+	if len(c.GetLCDEndpoints()) == 0 {
+		for _, api := range registryChain.APIs.REST {
+			c.LCDEndpoints = append(c.LCDEndpoints, api.Address)
+		}
+	}
+
+	if c.Bech32Prefix == "" {
+		c.Bech32Prefix = registryChain.Bech32Prefix
+	}
+
+	if c.CoinType == 0 {
+		c.CoinType = registryChain.Slip44
+	}
+
+	for _, asset := range assetList.Assets {
+		c.applyChainRegistryAsset(asset)
+	}
+
+	return nil
+}
+
+// applyChainRegistryAsset fills the missing fields of the configured denom from the asset,
+// or keeps the asset in RegistryDenoms if the denom is not configured.
+func (c *Chain) applyChainRegistryAsset(asset ChainRegistryAsset) {
+	denom := &DenomInfo{Denom: asset.Base}
+
+	configured := false
+	for index := range c.Denoms {
+		if c.Denoms[index].Denom == asset.Base {
+			denom = &c.Denoms[index]
+			configured = true
+			break
+		}
+	}
+
+	if denom.DisplayDenom == "" {
+		denom.DisplayDenom = asset.Display
+	}
+
+	if !denom.DenomExponent.Valid {
+		if exponent, found := asset.GetDisplayExponent(); found {
+			denom.DenomExponent = null.IntFrom(exponent)
+		}
+	}
+
+	if denom.CoingeckoCurrency == "" {
+		denom.CoingeckoCurrency = asset.CoingeckoID
+	}
+
+	if configured {
+		return
+	}
+
+	if c.RegistryDenoms == nil {
+		c.RegistryDenoms = make(map[string]DenomInfo)
+	}
+
+	c.RegistryDenoms[asset.Base] = *denom
+}
+
+func readJSONFile(filePath string, filesystem fs.FS, target interface{}) error {
+	bytes, err := filesystem.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(bytes, target); err != nil {
+		return fmt.Errorf("error parsing %s: %s", filePath, err)
+	}
+
+	return nil
+}
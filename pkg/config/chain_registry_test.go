@@ -0,0 +1,73 @@
+package config
+
+import (
+	"main/pkg/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFromChainRegistry(t *testing.T) {
+	t.Parallel()
+
+	filesystem := &fs.TestFS{}
+	config, err := GetConfig("config-chain-registry.toml", filesystem)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+	require.Len(t, config.Chains, 1)
+
+	chain := config.Chains[0]
+	assert.Equal(t, []string{
+		"https://cosmos-api.polkachu.com",
+		"https://rest-cosmoshub.ecostake.com",
+	}, chain.GetLCDEndpoints())
+	assert.Equal(t, "cosmos", chain.Bech32Prefix)
+	assert.Equal(t, int64(118), chain.CoinType)
+	require.Len(t, chain.Denoms, 1)
+
+	// set explicitly in the config, so only missing fields are taken from chain-registry
+	assert.Equal(t, "atest", chain.Denoms[0].Denom)
+	assert.Equal(t, "TEST", chain.Denoms[0].DisplayDenom)
+	assert.Equal(t, 9, chain.Denoms[0].GetExponent())
+	assert.Empty(t, chain.Denoms[0].CoingeckoCurrency)
+	assert.False(t, chain.IsRegistryDenom("atest"))
+
+	// not in the config, so it's only used to resolve wallet balances and not added to denoms
+	denom, found := chain.FindDenomByName("uatom")
+	require.True(t, found)
+	assert.Equal(t, "atom", denom.DisplayDenom)
+	assert.Equal(t, 6, denom.GetExponent())
+	assert.Equal(t, "cosmos", denom.CoingeckoCurrency)
+	assert.True(t, chain.IsRegistryDenom("uatom"))
+	assert.Len(t, chain.GetDenoms(), 1)
+}
+
+func TestChainLoadFromChainRegistryNotEnabled(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{Name: "chain"}
+	require.NoError(t, chain.LoadFromChainRegistry("", &fs.TestFS{}))
+	assert.Empty(t, chain.Denoms)
+}
+
+func TestChainLoadFromChainRegistryKeepsLCDEndpoints(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{Name: "chain", LCDEndpoint: "https://example.com", ChainRegistryName: "cosmoshub"}
+	require.NoError(t, chain.LoadFromChainRegistry("chain-registry", &fs.TestFS{}))
+	assert.Equal(t, []string{"https://example.com"}, chain.GetLCDEndpoints())
+}
+
+func TestChainLoadFromChainRegistryFail(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{Name: "chain", ChainRegistryName: "cosmoshub"}
+	require.ErrorContains(t, chain.LoadFromChainRegistry("", &fs.TestFS{}), "chain-registry-path is not set")
+
+	chain = &Chain{Name: "chain", ChainRegistryName: "not-found"}
+	require.Error(t, chain.LoadFromChainRegistry("chain-registry", &fs.TestFS{}))
+
+	chain = &Chain{Name: "chain", ChainRegistryName: "invalid"}
+	require.ErrorContains(t, chain.LoadFromChainRegistry("chain-registry", &fs.TestFS{}), "error parsing")
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"testing"
+	"time"
 
+	"github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -25,6 +27,24 @@ func TestChainNoLcdEndpoint(t *testing.T) {
 	require.ErrorContains(t, err, "no LCD endpoint provided")
 }
 
+func TestChainNegativeQueryInterval(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{Name: "chain", LCDEndpoint: "test", QueryInterval: -time.Second}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "query interval cannot be negative")
+}
+
+func TestChainNegativeDenomsMetadataRefreshInterval(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{Name: "chain", LCDEndpoint: "test", DenomsMetadataRefreshInterval: -time.Second}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "denoms metadata refresh interval cannot be negative")
+}
+
 func TestChainNoWallets(t *testing.T) {
 	t.Parallel()
 
@@ -43,13 +63,106 @@ func TestChainInvalidWallet(t *testing.T) {
 	require.ErrorContains(t, err, "error in wallet 0")
 }
 
+func TestChainInvalidWalletAddresses(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:         "chain",
+		LCDEndpoint:  "test",
+		Bech32Prefix: "osmo",
+		Wallets: []Wallet{
+			{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"},
+			{Address: "osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucv"},
+			{Address: "osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucw"},
+		},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in wallet 0: invalid address cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7: expected prefix osmo, got cosmos")
+	require.ErrorContains(t, err, "error in wallet 2: invalid address osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucw: invalid checksum")
+	require.NotContains(t, err.Error(), "error in wallet 1")
+}
+
+func TestChainInvalidDenom(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:        "chain",
+		LCDEndpoint: "test",
+		Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		Denoms:      []DenomInfo{{}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in denom 0")
+}
+
+func TestChainInvalidCW20Token(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:        "chain",
+		LCDEndpoint: "test",
+		Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		CW20Tokens:  []CW20Token{{}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in CW20 token 0")
+}
+
+func TestChainInvalidERC20Token(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:           "chain",
+		LCDEndpoint:    "test",
+		EVMRPCEndpoint: "test",
+		Wallets:        []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		ERC20Tokens:    []ERC20Token{{}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in ERC-20 token 0")
+}
+
+func TestChainNoEVMEndpoint(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:           "chain",
+		LCDEndpoint:    "test",
+		EVMNativeDenom: "aevmos",
+		Wallets:        []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "no EVM JSON-RPC endpoint provided")
+}
+
+func TestChainHexWalletWithoutPrefix(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:        "chain",
+		LCDEndpoint: "test",
+		Wallets:     []Wallet{{Address: "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "bech32-prefix is required for hex addresses")
+
+	chain.Bech32Prefix = "evmos"
+	require.NoError(t, chain.Validate())
+}
+
 func TestChainValid(t *testing.T) {
 	t.Parallel()
 
 	chain := &Chain{
 		Name:        "chain",
 		LCDEndpoint: "test",
-		Wallets:     []Wallet{{Address: "address"}},
+		Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
 	}
 	err := chain.Validate()
 	require.NoError(t, err)
@@ -72,3 +185,130 @@ func TestChainFindDenomByName(t *testing.T) {
 	require.Nil(t, denom2)
 	assert.False(t, found2)
 }
+
+func TestChainFindCW20DenomByName(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Denoms:     []DenomInfo{{Denom: "denom1"}},
+		CW20Tokens: []CW20Token{{Contract: "contract", DisplayDenom: "token", Decimals: null.IntFrom(8)}},
+	}
+
+	require.Len(t, chain.GetDenoms(), 2)
+
+	denom, found := chain.FindDenomByName("cw20:contract")
+	require.True(t, found)
+	assert.Equal(t, "token", denom.GetName())
+	assert.Equal(t, 8, denom.GetExponent())
+}
+
+func TestChainStakingQueriesEnabled(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		QueryDelegations:          null.BoolFrom(true),
+		QueryUnbondingDelegations: null.BoolFrom(false),
+	}
+
+	assert.True(t, chain.IsDelegationsQueryEnabled(Wallet{}))
+	assert.False(t, chain.IsDelegationsQueryEnabled(Wallet{QueryDelegations: null.BoolFrom(false)}))
+	assert.False(t, chain.IsUnbondingDelegationsQueryEnabled(Wallet{}))
+	assert.True(t, chain.IsUnbondingDelegationsQueryEnabled(Wallet{QueryUnbondingDelegations: null.BoolFrom(true)}))
+	assert.False(t, chain.IsRedelegationsQueryEnabled(Wallet{}))
+	assert.True(t, chain.IsRedelegationsQueryEnabled(Wallet{QueryRedelegations: null.BoolFrom(true)}))
+}
+
+func TestChainRewardsQueryEnabled(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{QueryRewards: null.BoolFrom(true)}
+
+	assert.True(t, chain.IsRewardsQueryEnabled(Wallet{}))
+	assert.False(t, chain.IsRewardsQueryEnabled(Wallet{QueryRewards: null.BoolFrom(false)}))
+	assert.False(t, (&Chain{}).IsRewardsQueryEnabled(Wallet{}))
+}
+
+func TestChainVestingQueryEnabled(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{QueryVesting: null.BoolFrom(true)}
+
+	assert.True(t, chain.IsVestingQueryEnabled(Wallet{}))
+	assert.False(t, chain.IsVestingQueryEnabled(Wallet{QueryVesting: null.BoolFrom(false)}))
+	assert.False(t, (&Chain{}).IsVestingQueryEnabled(Wallet{}))
+}
+
+func TestChainGetLCDEndpoints(t *testing.T) {
+	t.Parallel()
+
+	assert.Empty(t, (&Chain{}).GetLCDEndpoints())
+	assert.Equal(t, []string{"first"}, (&Chain{LCDEndpoint: "first"}).GetLCDEndpoints())
+	assert.Equal(t, []string{"first", "second"}, (&Chain{
+		LCDEndpoint:  "first",
+		LCDEndpoints: []string{"first", "second"},
+	}).GetLCDEndpoints())
+}
+
+func TestChainInvalidMaxBlockLag(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:         "chain",
+		LCDEndpoints: []string{"test"},
+		MaxBlockLag:  -1,
+		Wallets:      []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+	}
+	err := chain.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "max block lag cannot be negative")
+}
+
+func TestChainBalancesTransport(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:              "chain",
+		BalancesTransport: "unknown",
+		Wallets:           []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+	}
+	require.ErrorContains(t, chain.Validate(), "unsupported balances transport: unknown")
+
+	chain.BalancesTransport = BalancesTransportGRPC
+	require.ErrorContains(t, chain.Validate(), "no gRPC endpoint provided")
+
+	chain.GRPCEndpoint = "localhost:9090"
+	require.NoError(t, chain.Validate())
+
+	chain.BalancesTransport = BalancesTransportABCI
+	require.ErrorContains(t, chain.Validate(), "no RPC endpoint provided")
+
+	chain.RPCEndpoint = "https://rpc.example.com"
+	require.NoError(t, chain.Validate())
+}
+
+func TestChainLCDOnlyQueriesWithoutLCDEndpoint(t *testing.T) {
+	t.Parallel()
+
+	chain := &Chain{
+		Name:              "chain",
+		BalancesTransport: BalancesTransportGRPC,
+		GRPCEndpoint:      "localhost:9090",
+		QueryDelegations:  null.BoolFrom(true),
+		Wallets:           []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+	}
+	require.ErrorContains(t, chain.Validate(), "no LCD endpoint provided")
+
+	// disabled for the only wallet
+	chain.Wallets[0].QueryDelegations = null.BoolFrom(false)
+	require.NoError(t, chain.Validate())
+
+	chain.Wallets[0].QueryVesting = null.BoolFrom(true)
+	require.ErrorContains(t, chain.Validate(), "no LCD endpoint provided")
+
+	chain.Wallets[0].QueryVesting = null.BoolFrom(false)
+	chain.CW20Tokens = []CW20Token{{Contract: "contract"}}
+	require.ErrorContains(t, chain.Validate(), "no LCD endpoint provided")
+
+	chain.LCDEndpoint = "https://example.com"
+	require.NoError(t, chain.Validate())
+}
@@ -10,10 +10,25 @@ import (
 )
 
 type Config struct {
-	TracingConfig TracingConfig `toml:"tracing"`
-	LogConfig     LogConfig     `toml:"log"`
-	ListenAddress string        `default:":9550" toml:"listen-address"`
-	Chains        []Chain       `toml:"chains"`
+	TracingConfig       TracingConfig          `toml:"tracing"`
+	LogConfig           LogConfig              `toml:"log"`
+	APIConfig           APIConfig              `toml:"api"`
+	NotifierConfig      NotifierConfig         `toml:"notifier"`
+	HistoryConfig       HistoryConfig          `toml:"history"`
+	BurnRateConfig      BurnRateConfig         `toml:"burn-rate"`
+	ListenAddress       string                 `default:":9550"           toml:"listen-address"`
+	FiatCurrencies      []string               `default:"[\"usd\"]"       toml:"fiat-currencies"`
+	PriceProviders      []string               `default:"[\"coingecko\"]" toml:"price-providers"`
+	PriceCacheConfig    PriceCacheConfig       `toml:"price-cache"`
+	CoingeckoConfig     CoingeckoConfig        `toml:"coingecko"`
+	CoinMarketCapConfig CoinMarketCapConfig    `toml:"coinmarketcap"`
+	ChainRegistryPath   string                 `toml:"chain-registry-path"`
+	Chains              []Chain                `toml:"chains"`
+	SharedWallets       []SharedWallet         `toml:"shared-wallets"`
+	Groups              map[string]GroupConfig `toml:"groups"`
+
+	// Warnings are the config issues that are not errors, but should be logged on start.
+	Warnings []string `toml:"-"`
 }
 
 func (c *Config) Validate() error {
@@ -21,27 +36,74 @@ func (c *Config) Validate() error {
 		return errors.New("no chains provided")
 	}
 
+	for _, provider := range c.PriceProviders {
+		switch provider {
+		case PriceProviderCoingecko:
+			if err := c.CoingeckoConfig.Validate(); err != nil {
+				return fmt.Errorf("error in coingecko config: %s", err)
+			}
+		case PriceProviderCoinMarketCap:
+			if err := c.CoinMarketCapConfig.Validate(); err != nil {
+				return fmt.Errorf("error in coinmarketcap config: %s", err)
+			}
+		case PriceProviderStatic, PriceProviderOsmosis:
+		default:
+			return fmt.Errorf("unsupported price provider: %s", provider)
+		}
+	}
+
+	if err := c.PriceCacheConfig.Validate(); err != nil {
+		return fmt.Errorf("error in price cache config: %s", err)
+	}
+
+	if err := c.NotifierConfig.Validate(); err != nil {
+		return fmt.Errorf("error in notifier config: %s", err)
+	}
+
+	if err := c.HistoryConfig.Validate(); err != nil {
+		return fmt.Errorf("error in history config: %s", err)
+	}
+
+	if err := c.BurnRateConfig.Validate(); err != nil {
+		return fmt.Errorf("error in burn rate config: %s", err)
+	}
+
+	if err := c.ValidateGroups(); err != nil {
+		return err
+	}
+
+	chainErrors := []error{}
+
 	for index, chain := range c.Chains {
+		prefix := fmt.Sprintf("error in chain %d (%s)", index, chain.Name)
+
 		if err := chain.Validate(); err != nil {
-			return fmt.Errorf("error in chain %d: %s", index, err)
+			chainErrors = append(chainErrors, prefixErrors(prefix, err)...)
+			continue
+		}
+
+		if err := c.ValidateWalletThresholds(chain); err != nil {
+			chainErrors = append(chainErrors, prefixErrors(prefix, err)...)
 		}
 	}
 
-	return nil
+	return errors.Join(chainErrors...)
 }
 
-func (c *Config) GetCoingeckoCurrencies() []string {
-	currencies := []string{}
+// prefixErrors adds the prefix to the error, or to each of them if it's
+// an errors.Join result, so every line of the joined error has the context.
+func prefixErrors(prefix string, err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{fmt.Errorf("%s: %s", prefix, err)}
+	}
 
-	for _, chain := range c.Chains {
-		for _, denom := range chain.Denoms {
-			if denom.CoingeckoCurrency != "" {
-				currencies = append(currencies, denom.CoingeckoCurrency)
-			}
-		}
+	prefixed := []error{}
+	for _, inner := range joined.Unwrap() {
+		prefixed = append(prefixed, prefixErrors(prefix, inner)...)
 	}
 
-	return currencies
+	return prefixed
 }
 
 func GetConfig(path string, filesystem fs.FS) (*Config, error) {
@@ -57,6 +119,39 @@ func GetConfig(path string, filesystem fs.FS) (*Config, error) {
 		return nil, err
 	}
 
+	for index := range configStruct.Chains {
+		chain := &configStruct.Chains[index]
+		if err := chain.LoadFromChainRegistry(configStruct.ChainRegistryPath, filesystem); err != nil {
+			return nil, fmt.Errorf("error loading chain %s from chain-registry: %s", chain.Name, err)
+		}
+	}
+
 	defaults.MustSet(&configStruct)
+
+	warnings, err := configStruct.DeriveSharedWallets()
+	if err != nil {
+		return nil, err
+	}
+
+	if configStruct.HistoryConfig.Enabled && !configStruct.APIConfig.Enabled {
+		warnings = append(
+			warnings,
+			"balances history is enabled, but the API is disabled, so the history is recorded, "+
+				"but cannot be read via /api/history; set enabled = true in the [api] section to serve it",
+		)
+	}
+
+	configStruct.Warnings = warnings
 	return &configStruct, nil
 }
+
+// FindChainByName returns a pointer to the chain in the config, so it can be modified in place.
+func (c *Config) FindChainByName(name string) (*Chain, bool) {
+	for index := range c.Chains {
+		if c.Chains[index].Name == name {
+			return &c.Chains[index], true
+		}
+	}
+
+	return nil, false
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"main/pkg/fs"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,33 +27,132 @@ func TestConfigInvalidChain(t *testing.T) {
 	require.ErrorContains(t, err, "error in chain 0")
 }
 
+func TestConfigInvalidChainsWallets(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PriceProviders: []string{"static"},
+		Chains: []Chain{
+			{
+				Name:         "cosmos",
+				LCDEndpoint:  "test",
+				Bech32Prefix: "cosmos",
+				Wallets: []Wallet{
+					{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"},
+					{Address: "osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucv"},
+				},
+			},
+			{
+				Name:         "osmosis",
+				LCDEndpoint:  "test",
+				Bech32Prefix: "osmo",
+				Wallets: []Wallet{
+					{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"},
+				},
+			},
+		},
+	}
+
+	err := config.Validate()
+	require.Error(t, err)
+	require.Equal(
+		t,
+		"error in chain 0 (cosmos): error in wallet 1: invalid address osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucv: expected prefix cosmos, got osmo\n"+
+			"error in chain 1 (osmosis): error in wallet 0: invalid address cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7: expected prefix osmo, got cosmos",
+		err.Error(),
+	)
+}
+
 func TestConfigValid(t *testing.T) {
 	t.Parallel()
 
 	chain := &Config{Chains: []Chain{{
 		Name:        "chain",
 		LCDEndpoint: "test",
-		Wallets:     []Wallet{{Address: "address"}},
+		Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
 	}}}
 	err := chain.Validate()
 	require.NoError(t, err)
 }
 
-func TestGetCoingeckoCurrencies(t *testing.T) {
+func TestConfigInvalidPriceProvider(t *testing.T) {
 	t.Parallel()
 
-	chain := &Config{Chains: []Chain{{
-		Name:        "chain",
-		LCDEndpoint: "test",
-		Wallets:     []Wallet{{Address: "address"}},
-		Denoms: []DenomInfo{
-			{Denom: "uatom", DisplayDenom: "atom", CoingeckoCurrency: "cosmos"},
-			{Denom: "unom", DisplayDenom: "nom"},
-		},
-	}}}
-	currencies := chain.GetCoingeckoCurrencies()
-	require.Len(t, currencies, 1)
-	assert.Equal(t, "cosmos", currencies[0])
+	config := &Config{
+		PriceProviders: []string{"unknown"},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "test",
+			Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		}},
+	}
+	err := config.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "unsupported price provider: unknown")
+}
+
+func TestConfigInvalidCoingeckoConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PriceProviders: []string{PriceProviderCoingecko},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "test",
+			Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		}},
+	}
+	err := config.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in coingecko config")
+}
+
+func TestConfigInvalidCoinMarketCapConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PriceProviders: []string{PriceProviderCoinMarketCap},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "test",
+			Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		}},
+	}
+	err := config.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in coinmarketcap config")
+}
+
+func TestConfigInvalidPriceCacheConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PriceCacheConfig: PriceCacheConfig{TTL: -time.Second},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "test",
+			Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		}},
+	}
+	err := config.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "error in price cache config")
+}
+
+func TestConfigValidPriceProviders(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		PriceProviders:      []string{PriceProviderCoingecko, PriceProviderCoinMarketCap, PriceProviderStatic},
+		CoingeckoConfig:     CoingeckoConfig{APIKeyType: CoingeckoAPIKeyTypeDemo},
+		CoinMarketCapConfig: CoinMarketCapConfig{APIKey: "key"},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "test",
+			Wallets:     []Wallet{{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}},
+		}},
+	}
+	require.NoError(t, config.Validate())
 }
 
 func TestLoadConfigFailedToLoad(t *testing.T) {
@@ -80,4 +180,38 @@ func TestLoadConfigValid(t *testing.T) {
 	config, err := GetConfig("config-valid.toml", filesystem)
 	require.NotNil(t, config)
 	require.NoError(t, err)
+	assert.Equal(t, []string{"usd"}, config.FiatCurrencies)
+
+	require.Len(t, config.Chains, 1)
+	require.Len(t, config.Chains[0].Denoms, 2)
+	assert.False(t, config.Chains[0].Denoms[0].DenomExponent.Valid)
+	assert.Equal(t, 18, config.Chains[0].Denoms[1].GetExponent())
+	assert.True(t, config.Chains[0].DiscoverDenomsMetadata.Bool)
+	assert.Equal(t, time.Hour, config.Chains[0].DenomsMetadataRefreshInterval)
+}
+
+func TestLoadConfigHistoryWithoutAPI(t *testing.T) {
+	t.Parallel()
+
+	filesystem := &fs.TestFS{}
+	config, err := GetConfig("config-invalid-history-path.toml", filesystem)
+	require.NoError(t, err)
+	require.NotNil(t, config)
+	require.Len(t, config.Warnings, 1)
+	assert.Contains(t, config.Warnings[0], "the API is disabled")
+}
+
+func TestConfigFindChainByName(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Chains: []Chain{{Name: "chain"}}}
+
+	chain, found := config.FindChainByName("chain")
+	require.NotNil(t, chain)
+	assert.True(t, found)
+	assert.Same(t, &config.Chains[0], chain)
+
+	chain, found = config.FindChainByName("unknown")
+	require.Nil(t, chain)
+	assert.False(t, found)
 }
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/v5"
+)
+
+// CW20DenomPrefix is the prefix CW20 token balances are stored with as denoms,
+// the same way CW20 tokens are referred to in ICS20 transfers.
+const CW20DenomPrefix = "cw20:"
+
+type CW20Token struct {
+	Contract              string             `toml:"contract"`
+	DisplayDenom          string             `toml:"display-denom"`
+	Decimals              null.Int           `toml:"decimals"`
+	CoingeckoCurrency     string             `toml:"coingecko-currency"`
+	CoinMarketCapCurrency string             `toml:"coinmarketcap-currency"`
+	StaticPrices          map[string]float64 `toml:"static-prices"`
+}
+
+func (t CW20Token) Validate() error {
+	if t.Contract == "" {
+		return errors.New("empty contract address")
+	}
+
+	if t.Decimals.Valid && t.Decimals.Int64 < 0 {
+		return errors.New("decimals cannot be negative")
+	}
+
+	return nil
+}
+
+func (t CW20Token) GetDenom() string {
+	return CW20DenomPrefix + t.Contract
+}
+
+// ToDenomInfo converts the token to a denom, so it is handled as any other denom
+// when getting its display name, exponent and price.
+func (t CW20Token) ToDenomInfo() DenomInfo {
+	return DenomInfo{
+		Denom:                 t.GetDenom(),
+		DisplayDenom:          t.DisplayDenom,
+		DenomExponent:         t.Decimals,
+		CoingeckoCurrency:     t.CoingeckoCurrency,
+		CoinMarketCapCurrency: t.CoinMarketCapCurrency,
+		StaticPrices:          t.StaticPrices,
+	}
+}
+
+func IsCW20Denom(denom string) bool {
+	return strings.HasPrefix(denom, CW20DenomPrefix)
+}
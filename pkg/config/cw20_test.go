@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCW20TokenValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, CW20Token{}.Validate(), "empty contract address")
+	require.ErrorContains(t, CW20Token{
+		Contract: "contract",
+		Decimals: null.IntFrom(-1),
+	}.Validate(), "decimals cannot be negative")
+	require.NoError(t, CW20Token{Contract: "contract"}.Validate())
+}
+
+func TestCW20TokenToDenomInfo(t *testing.T) {
+	t.Parallel()
+
+	denom := CW20Token{
+		Contract:          "contract",
+		DisplayDenom:      "token",
+		Decimals:          null.IntFrom(8),
+		CoingeckoCurrency: "token-id",
+	}.ToDenomInfo()
+
+	assert.Equal(t, "cw20:contract", denom.Denom)
+	assert.Equal(t, "token", denom.DisplayDenom)
+	assert.Equal(t, 8, denom.GetExponent())
+	assert.Equal(t, "token-id", denom.CoingeckoCurrency)
+	assert.True(t, IsCW20Denom(denom.Denom))
+	assert.False(t, IsCW20Denom("uatom"))
+}
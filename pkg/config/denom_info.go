@@ -1,10 +1,42 @@
 package config
 
+import (
+	"errors"
+	"fmt"
+
+	"github.com/guregu/null/v5"
+)
+
+// DefaultDenomExponent is used if the exponent is neither set in the config
+// nor returned in the chain denoms metadata.
+const DefaultDenomExponent = 6
+
 type DenomInfo struct {
-	Denom             string `toml:"denom"`
-	DisplayDenom      string `toml:"display-denom"`
-	DenomExponent     int    `default:"6"               toml:"denom-exponent"`
-	CoingeckoCurrency string `toml:"coingecko-currency"`
+	Denom                 string              `toml:"denom"`
+	DisplayDenom          string              `toml:"display-denom"`
+	DenomExponent         null.Int            `toml:"denom-exponent"`
+	CoingeckoCurrency     string              `toml:"coingecko-currency"`
+	CoinMarketCapCurrency string              `toml:"coinmarketcap-currency"`
+	StaticPrices          map[string]float64  `toml:"static-prices"`
+	OsmosisPrice          *OsmosisPriceConfig `toml:"osmosis-price"`
+}
+
+func (d DenomInfo) Validate() error {
+	if d.Denom == "" {
+		return errors.New("empty denom")
+	}
+
+	if d.DenomExponent.Valid && d.DenomExponent.Int64 < 0 {
+		return errors.New("denom exponent cannot be negative")
+	}
+
+	if d.OsmosisPrice != nil {
+		if err := d.OsmosisPrice.Validate(); err != nil {
+			return fmt.Errorf("error in Osmosis price config: %s", err)
+		}
+	}
+
+	return nil
 }
 
 func (d DenomInfo) GetName() string {
@@ -14,3 +46,11 @@ func (d DenomInfo) GetName() string {
 
 	return d.Denom
 }
+
+func (d DenomInfo) GetExponent() int {
+	if d.DenomExponent.Valid {
+		return int(d.DenomExponent.Int64)
+	}
+
+	return DefaultDenomExponent
+}
@@ -3,7 +3,9 @@ package config
 import (
 	"testing"
 
+	"github.com/guregu/null/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestDenomInfoGetName(t *testing.T) {
@@ -12,3 +14,26 @@ func TestDenomInfoGetName(t *testing.T) {
 	assert.Equal(t, "denom", DenomInfo{Denom: "denom"}.GetName())
 	assert.Equal(t, "display", DenomInfo{Denom: "denom", DisplayDenom: "display"}.GetName())
 }
+
+func TestDenomInfoValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, DenomInfo{}.Validate(), "empty denom")
+	require.ErrorContains(t, DenomInfo{
+		Denom:        "denom",
+		OsmosisPrice: &OsmosisPriceConfig{},
+	}.Validate(), "error in Osmosis price config")
+	require.ErrorContains(t, DenomInfo{
+		Denom:         "denom",
+		DenomExponent: null.IntFrom(-1),
+	}.Validate(), "denom exponent cannot be negative")
+	require.NoError(t, DenomInfo{Denom: "denom"}.Validate())
+}
+
+func TestDenomInfoGetExponent(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, DefaultDenomExponent, DenomInfo{Denom: "denom"}.GetExponent())
+	assert.Equal(t, 18, DenomInfo{Denom: "denom", DenomExponent: null.IntFrom(18)}.GetExponent())
+	assert.Equal(t, 0, DenomInfo{Denom: "denom", DenomExponent: null.IntFrom(0)}.GetExponent())
+}
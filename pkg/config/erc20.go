@@ -0,0 +1,62 @@
+package config
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/guregu/null/v5"
+)
+
+// ERC20DenomPrefix is the prefix ERC-20 token balances are stored with as denoms.
+const ERC20DenomPrefix = "erc20:"
+
+// DefaultERC20Decimals is used if decimals are not set for an ERC-20 token,
+// as most of them have 18 decimals, the same as ETH.
+const DefaultERC20Decimals = 18
+
+type ERC20Token struct {
+	Contract              string             `toml:"contract"`
+	DisplayDenom          string             `toml:"display-denom"`
+	Decimals              null.Int           `toml:"decimals"`
+	CoingeckoCurrency     string             `toml:"coingecko-currency"`
+	CoinMarketCapCurrency string             `toml:"coinmarketcap-currency"`
+	StaticPrices          map[string]float64 `toml:"static-prices"`
+}
+
+func (t ERC20Token) Validate() error {
+	if t.Contract == "" {
+		return errors.New("empty contract address")
+	}
+
+	if !strings.HasPrefix(t.Contract, "0x") {
+		return errors.New("contract address should be a 0x-prefixed hex address")
+	}
+
+	if t.Decimals.Valid && t.Decimals.Int64 < 0 {
+		return errors.New("decimals cannot be negative")
+	}
+
+	return nil
+}
+
+func (t ERC20Token) GetDenom() string {
+	return ERC20DenomPrefix + strings.ToLower(t.Contract)
+}
+
+// ToDenomInfo converts the token to a denom, so it is handled as any other denom
+// when getting its display name, exponent and price.
+func (t ERC20Token) ToDenomInfo() DenomInfo {
+	decimals := t.Decimals
+	if !decimals.Valid {
+		decimals = null.IntFrom(DefaultERC20Decimals)
+	}
+
+	return DenomInfo{
+		Denom:                 t.GetDenom(),
+		DisplayDenom:          t.DisplayDenom,
+		DenomExponent:         decimals,
+		CoingeckoCurrency:     t.CoingeckoCurrency,
+		CoinMarketCapCurrency: t.CoinMarketCapCurrency,
+		StaticPrices:          t.StaticPrices,
+	}
+}
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestERC20TokenValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, ERC20Token{}.Validate(), "empty contract address")
+	require.ErrorContains(t, ERC20Token{Contract: "contract"}.Validate(), "0x-prefixed hex address")
+	require.ErrorContains(t, ERC20Token{
+		Contract: "0xcontract",
+		Decimals: null.IntFrom(-1),
+	}.Validate(), "decimals cannot be negative")
+	require.NoError(t, ERC20Token{Contract: "0xcontract"}.Validate())
+}
+
+func TestERC20TokenToDenomInfo(t *testing.T) {
+	t.Parallel()
+
+	denom := ERC20Token{Contract: "0xCONTRACT", DisplayDenom: "token"}.ToDenomInfo()
+	assert.Equal(t, "erc20:0xcontract", denom.Denom)
+	assert.Equal(t, "token", denom.DisplayDenom)
+	assert.Equal(t, DefaultERC20Decimals, denom.GetExponent())
+
+	denom = ERC20Token{Contract: "0xcontract", Decimals: null.IntFrom(6)}.ToDenomInfo()
+	assert.Equal(t, 6, denom.GetExponent())
+}
@@ -0,0 +1,36 @@
+package config
+
+import (
+	"errors"
+	"time"
+)
+
+// HistoryConfig is the balances history storage config, recording every successful
+// wallet balances query into an embedded BoltDB file to be read back via the API.
+type HistoryConfig struct {
+	Enabled bool   `default:"false"      toml:"enabled"`
+	Path    string `default:"history.db" toml:"path"`
+	// Retention is how long the records are kept for, 0 to keep them forever.
+	Retention       time.Duration `toml:"retention"`
+	CleanupInterval time.Duration `default:"1h" toml:"cleanup-interval"`
+}
+
+func (c HistoryConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.Path == "" {
+		return errors.New("empty path")
+	}
+
+	if c.Retention < 0 {
+		return errors.New("retention cannot be negative")
+	}
+
+	if c.Retention > 0 && c.CleanupInterval <= 0 {
+		return errors.New("cleanup interval should be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		config HistoryConfig
+		err    string
+	}{
+		{"disabled", HistoryConfig{}, ""},
+		{"empty path", HistoryConfig{Enabled: true}, "empty path"},
+		{"negative retention", HistoryConfig{Enabled: true, Path: "history.db", Retention: -time.Hour}, "retention cannot be negative"},
+		{
+			"no cleanup interval",
+			HistoryConfig{Enabled: true, Path: "history.db", Retention: time.Hour},
+			"cleanup interval should be positive",
+		},
+		{"keep forever", HistoryConfig{Enabled: true, Path: "history.db"}, ""},
+		{"valid", HistoryConfig{Enabled: true, Path: "history.db", Retention: time.Hour, CleanupInterval: time.Minute}, ""},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.config.Validate()
+		if testCase.err == "" {
+			require.NoError(t, err, testCase.name)
+		} else {
+			require.ErrorContains(t, err, testCase.err, testCase.name)
+		}
+	}
+}
+
+func TestLoadHistoryConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{}
+	_, err := toml.Decode(`
+[history]
+enabled = true
+retention = "720h"
+`, config)
+	require.NoError(t, err)
+	require.NoError(t, defaults.Set(config))
+
+	assert.True(t, config.HistoryConfig.Enabled)
+	assert.Equal(t, "history.db", config.HistoryConfig.Path)
+	assert.Equal(t, 720*time.Hour, config.HistoryConfig.Retention)
+	assert.Equal(t, time.Hour, config.HistoryConfig.CleanupInterval)
+	require.NoError(t, config.HistoryConfig.Validate())
+}
@@ -0,0 +1,124 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/guregu/null/v5"
+)
+
+// NotifierConfig is the built-in alerting config, sending notifications when the wallets
+// balances go below their thresholds (see BalanceThresholds), for setups without Alertmanager.
+type NotifierConfig struct {
+	Enabled        bool             `default:"false" toml:"enabled"`
+	RepeatInterval time.Duration    `default:"4h"    toml:"repeat-interval"`
+	SendResolved   null.Bool        `default:"true"  toml:"send-resolved"`
+	Webhooks       []WebhookConfig  `toml:"webhooks"`
+	Telegram       []TelegramConfig `toml:"telegram"`
+	Slack          []SlackConfig    `toml:"slack"`
+	Silences       []SilenceConfig  `toml:"silences"`
+}
+
+// WebhookConfig is a generic HTTP webhook, receiving the alerts as JSON.
+type WebhookConfig struct {
+	URL     string            `toml:"url"`
+	Headers map[string]string `toml:"headers"`
+}
+
+type TelegramConfig struct {
+	BotToken string `toml:"bot-token"`
+	ChatID   string `toml:"chat-id"`
+	APIURL   string `default:"https://api.telegram.org" toml:"api-url"`
+}
+
+// SlackConfig is a Slack incoming webhook, or any Slack-compatible one
+// (like Mattermost or Rocket.Chat), receiving the alerts as {"text": "..."}.
+type SlackConfig struct {
+	WebhookURL string `toml:"webhook-url"`
+}
+
+// SilenceConfig is a time window to not send notifications in, for the alerts
+// matching all of its non-empty matchers, or for all of them if there are none.
+type SilenceConfig struct {
+	Start   time.Time `toml:"start"`
+	End     time.Time `toml:"end"`
+	Chain   string    `toml:"chain"`
+	Address string    `toml:"address"`
+	Name    string    `toml:"name"`
+	Group   string    `toml:"group"`
+	Denom   string    `toml:"denom"`
+	Comment string    `toml:"comment"`
+}
+
+func (c NotifierConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.RepeatInterval < 0 {
+		return errors.New("repeat interval cannot be negative")
+	}
+
+	if len(c.Webhooks) == 0 && len(c.Telegram) == 0 && len(c.Slack) == 0 {
+		return errors.New("no webhooks, telegram or slack provided")
+	}
+
+	for index, webhook := range c.Webhooks {
+		if webhook.URL == "" {
+			return fmt.Errorf("error in webhook %d: empty url", index)
+		}
+	}
+
+	for index, telegram := range c.Telegram {
+		if telegram.BotToken == "" {
+			return fmt.Errorf("error in telegram %d: empty bot-token", index)
+		}
+
+		if telegram.ChatID == "" {
+			return fmt.Errorf("error in telegram %d: empty chat-id", index)
+		}
+	}
+
+	for index, slack := range c.Slack {
+		if slack.WebhookURL == "" {
+			return fmt.Errorf("error in slack %d: empty webhook-url", index)
+		}
+	}
+
+	for index, silence := range c.Silences {
+		if err := silence.Validate(); err != nil {
+			return fmt.Errorf("error in silence %d: %s", index, err)
+		}
+	}
+
+	return nil
+}
+
+func (s SilenceConfig) Validate() error {
+	if s.Start.IsZero() || s.End.IsZero() {
+		return errors.New("both start and end should be specified")
+	}
+
+	if !s.End.After(s.Start) {
+		return errors.New("end should be after start")
+	}
+
+	return nil
+}
+
+func (s SilenceConfig) IsActive(now time.Time) bool {
+	return !now.Before(s.Start) && now.Before(s.End)
+}
+
+func (s SilenceConfig) Matches(chain string, wallet Wallet, denom string) bool {
+	return matchesIfSet(s.Chain, chain) &&
+		matchesIfSet(s.Address, wallet.Address) &&
+		matchesIfSet(s.Name, wallet.Name) &&
+		matchesIfSet(s.Group, wallet.Group) &&
+		matchesIfSet(s.Denom, denom)
+}
+
+func matchesIfSet(matcher string, value string) bool {
+	return matcher == "" || matcher == value
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/creasty/defaults"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotifierConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	slack := []SlackConfig{{WebhookURL: "https://example.com"}}
+
+	testCases := []struct {
+		name   string
+		config NotifierConfig
+		err    string
+	}{
+		{"disabled", NotifierConfig{RepeatInterval: -time.Second}, ""},
+		{"negative repeat interval", NotifierConfig{Enabled: true, RepeatInterval: -time.Second, Slack: slack}, "repeat interval cannot be negative"},
+		{"no channels", NotifierConfig{Enabled: true}, "no webhooks, telegram or slack provided"},
+		{"empty webhook", NotifierConfig{Enabled: true, Webhooks: []WebhookConfig{{}}}, "error in webhook 0: empty url"},
+		{"no bot token", NotifierConfig{Enabled: true, Telegram: []TelegramConfig{{ChatID: "1"}}}, "error in telegram 0: empty bot-token"},
+		{"no chat id", NotifierConfig{Enabled: true, Telegram: []TelegramConfig{{BotToken: "token"}}}, "error in telegram 0: empty chat-id"},
+		{"empty slack", NotifierConfig{Enabled: true, Slack: []SlackConfig{{}}}, "error in slack 0: empty webhook-url"},
+		{
+			"silence without end",
+			NotifierConfig{Enabled: true, Slack: slack, Silences: []SilenceConfig{{Start: start}}},
+			"error in silence 0: both start and end should be specified",
+		},
+		{
+			"silence ending before start",
+			NotifierConfig{Enabled: true, Slack: slack, Silences: []SilenceConfig{{Start: start, End: start}}},
+			"error in silence 0: end should be after start",
+		},
+		{
+			"valid",
+			NotifierConfig{Enabled: true, Slack: slack, Silences: []SilenceConfig{{Start: start, End: start.Add(time.Hour)}}},
+			"",
+		},
+	}
+
+	for _, testCase := range testCases {
+		err := testCase.config.Validate()
+		if testCase.err == "" {
+			require.NoError(t, err, testCase.name)
+		} else {
+			require.ErrorContains(t, err, testCase.err, testCase.name)
+		}
+	}
+}
+
+func TestSilenceConfigIsActive(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	silence := SilenceConfig{Start: start, End: start.Add(time.Hour)}
+
+	assert.False(t, silence.IsActive(start.Add(-time.Second)))
+	assert.True(t, silence.IsActive(start))
+	assert.True(t, silence.IsActive(start.Add(30*time.Minute)))
+	assert.False(t, silence.IsActive(start.Add(time.Hour)))
+}
+
+func TestSilenceConfigMatches(t *testing.T) {
+	t.Parallel()
+
+	wallet := Wallet{Address: "address", Name: "name", Group: "group"}
+
+	assert.True(t, SilenceConfig{}.Matches("chain", wallet, "atom"))
+	assert.True(t, SilenceConfig{Chain: "chain", Group: "group"}.Matches("chain", wallet, "atom"))
+	assert.True(t, SilenceConfig{Address: "address", Name: "name", Denom: "atom"}.Matches("chain", wallet, "atom"))
+	assert.False(t, SilenceConfig{Chain: "chain", Group: "other"}.Matches("chain", wallet, "atom"))
+	assert.False(t, SilenceConfig{Denom: "osmo"}.Matches("chain", wallet, "atom"))
+}
+
+func TestLoadNotifierConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{}
+	_, err := toml.Decode(`
+[notifier]
+enabled = true
+repeat-interval = "1h"
+
+[[notifier.telegram]]
+bot-token = "token"
+chat-id = "123"
+
+[[notifier.silences]]
+start = 2024-01-01T00:00:00Z
+end = 2024-01-01T02:00:00Z
+group = "validator"
+`, config)
+	require.NoError(t, err)
+	require.NoError(t, defaults.Set(config))
+
+	assert.True(t, config.NotifierConfig.Enabled)
+	assert.Equal(t, time.Hour, config.NotifierConfig.RepeatInterval)
+	assert.True(t, config.NotifierConfig.SendResolved.Bool)
+	assert.Equal(t, "https://api.telegram.org", config.NotifierConfig.Telegram[0].APIURL)
+	assert.Equal(t, 2*time.Hour, config.NotifierConfig.Silences[0].End.Sub(config.NotifierConfig.Silences[0].Start))
+	require.NoError(t, config.NotifierConfig.Validate())
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	PriceProviderCoingecko     = "coingecko"
+	PriceProviderCoinMarketCap = "coinmarketcap"
+	PriceProviderStatic        = "static"
+	PriceProviderOsmosis       = "osmosis"
+
+	CoingeckoAPIKeyTypeDemo = "demo"
+	CoingeckoAPIKeyTypePro  = "pro"
+)
+
+type PriceCacheConfig struct {
+	TTL          time.Duration `default:"5m" toml:"ttl"`
+	MaxStaleness time.Duration `default:"1h" toml:"max-staleness"`
+}
+
+func (c PriceCacheConfig) Validate() error {
+	if c.TTL < 0 {
+		return errors.New("TTL cannot be negative")
+	}
+
+	if c.MaxStaleness < c.TTL {
+		return errors.New("max staleness cannot be less than TTL")
+	}
+
+	return nil
+}
+
+type CoingeckoConfig struct {
+	APIKey     string `toml:"api-key"`
+	APIKeyType string `default:"demo"   toml:"api-key-type"`
+	BaseURL    string `toml:"base-url"`
+}
+
+func (c CoingeckoConfig) Validate() error {
+	if c.APIKeyType != CoingeckoAPIKeyTypeDemo && c.APIKeyType != CoingeckoAPIKeyTypePro {
+		return fmt.Errorf("unsupported API key type: %s", c.APIKeyType)
+	}
+
+	return nil
+}
+
+func (c CoingeckoConfig) GetBaseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+
+	if c.APIKey != "" && c.APIKeyType == CoingeckoAPIKeyTypePro {
+		return "https://pro-api.coingecko.com/api/v3"
+	}
+
+	return "https://api.coingecko.com/api/v3"
+}
+
+type CoinMarketCapConfig struct {
+	APIKey  string `toml:"api-key"`
+	BaseURL string `default:"https://pro-api.coinmarketcap.com" toml:"base-url"`
+}
+
+func (c CoinMarketCapConfig) Validate() error {
+	if c.APIKey == "" {
+		return errors.New("API key is not specified")
+	}
+
+	return nil
+}
+
+type OsmosisPriceConfig struct {
+	PoolID         uint64 `toml:"pool-id"`
+	LCDEndpoint    string `toml:"lcd-endpoint"`
+	QuoteChain     string `toml:"quote-chain"`
+	QuoteDenom     string `toml:"quote-denom"`
+	PoolBaseDenom  string `toml:"pool-base-denom"`
+	PoolQuoteDenom string `toml:"pool-quote-denom"`
+}
+
+func (c OsmosisPriceConfig) Validate() error {
+	if c.PoolID == 0 {
+		return errors.New("pool ID is not specified")
+	}
+
+	if c.LCDEndpoint == "" {
+		return errors.New("LCD endpoint is not specified")
+	}
+
+	if c.QuoteChain == "" {
+		return errors.New("quote chain is not specified")
+	}
+
+	if c.QuoteDenom == "" {
+		return errors.New("quote denom is not specified")
+	}
+
+	return nil
+}
+
+func (c OsmosisPriceConfig) GetPoolBaseDenom(denom string) string {
+	if c.PoolBaseDenom != "" {
+		return c.PoolBaseDenom
+	}
+
+	return denom
+}
+
+func (c OsmosisPriceConfig) GetPoolQuoteDenom() string {
+	if c.PoolQuoteDenom != "" {
+		return c.PoolQuoteDenom
+	}
+
+	return c.QuoteDenom
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoingeckoConfigInvalidAPIKeyType(t *testing.T) {
+	t.Parallel()
+
+	config := CoingeckoConfig{APIKeyType: "invalid"}
+	err := config.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "unsupported API key type")
+}
+
+func TestCoingeckoConfigValid(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, CoingeckoConfig{APIKeyType: CoingeckoAPIKeyTypeDemo}.Validate())
+	require.NoError(t, CoingeckoConfig{APIKeyType: CoingeckoAPIKeyTypePro}.Validate())
+}
+
+func TestCoingeckoConfigGetBaseURL(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(
+		t,
+		"https://api.coingecko.com/api/v3",
+		CoingeckoConfig{APIKeyType: CoingeckoAPIKeyTypeDemo}.GetBaseURL(),
+	)
+	assert.Equal(
+		t,
+		"https://api.coingecko.com/api/v3",
+		CoingeckoConfig{APIKeyType: CoingeckoAPIKeyTypePro}.GetBaseURL(),
+	)
+	assert.Equal(
+		t,
+		"https://pro-api.coingecko.com/api/v3",
+		CoingeckoConfig{APIKey: "key", APIKeyType: CoingeckoAPIKeyTypePro}.GetBaseURL(),
+	)
+	assert.Equal(
+		t,
+		"https://example.com",
+		CoingeckoConfig{APIKey: "key", BaseURL: "https://example.com"}.GetBaseURL(),
+	)
+}
+
+func TestCoinMarketCapConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	err := CoinMarketCapConfig{}.Validate()
+	require.Error(t, err)
+	require.ErrorContains(t, err, "API key is not specified")
+
+	require.NoError(t, CoinMarketCapConfig{APIKey: "key"}.Validate())
+}
+
+func TestOsmosisPriceConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, OsmosisPriceConfig{}.Validate(), "pool ID is not specified")
+	require.ErrorContains(t, OsmosisPriceConfig{PoolID: 1}.Validate(), "LCD endpoint is not specified")
+	require.ErrorContains(t, OsmosisPriceConfig{
+		PoolID:      1,
+		LCDEndpoint: "https://example.com",
+	}.Validate(), "quote chain is not specified")
+	require.ErrorContains(t, OsmosisPriceConfig{
+		PoolID:      1,
+		LCDEndpoint: "https://example.com",
+		QuoteChain:  "osmosis",
+	}.Validate(), "quote denom is not specified")
+	require.NoError(t, OsmosisPriceConfig{
+		PoolID:      1,
+		LCDEndpoint: "https://example.com",
+		QuoteChain:  "osmosis",
+		QuoteDenom:  "uosmo",
+	}.Validate())
+}
+
+func TestOsmosisPriceConfigPoolDenoms(t *testing.T) {
+	t.Parallel()
+
+	config := OsmosisPriceConfig{QuoteDenom: "uosmo"}
+	assert.Equal(t, "denom", config.GetPoolBaseDenom("denom"))
+	assert.Equal(t, "uosmo", config.GetPoolQuoteDenom())
+
+	config = OsmosisPriceConfig{QuoteDenom: "uosmo", PoolBaseDenom: "ibc/BASE", PoolQuoteDenom: "ibc/QUOTE"}
+	assert.Equal(t, "ibc/BASE", config.GetPoolBaseDenom("denom"))
+	assert.Equal(t, "ibc/QUOTE", config.GetPoolQuoteDenom())
+}
+
+func TestPriceCacheConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, PriceCacheConfig{TTL: -time.Second}.Validate(), "TTL cannot be negative")
+	require.ErrorContains(t, PriceCacheConfig{
+		TTL:          time.Hour,
+		MaxStaleness: time.Minute,
+	}.Validate(), "max staleness cannot be less than TTL")
+	require.NoError(t, PriceCacheConfig{TTL: time.Minute, MaxStaleness: time.Hour}.Validate())
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"main/pkg/bech32"
+	"strings"
+)
+
+const (
+	// CoinTypeCosmos is the SLIP-44 coin type most cosmos-sdk chains use.
+	CoinTypeCosmos = 118
+	// CoinTypeEthereum is the SLIP-44 coin type used by Cosmos EVM chains, with ethsecp256k1 keys.
+	CoinTypeEthereum = 60
+)
+
+// SharedWallet is a wallet with the same key on multiple chains, which addresses
+// are derived by encoding the same address bytes with each chain bech32 prefix.
+type SharedWallet struct {
+	Address  string   `toml:"address"`
+	PubKey   string   `toml:"pubkey"`
+	Name     string   `toml:"name"`
+	Group    string   `toml:"group"`
+	CoinType int64    `default:"118" toml:"coin-type"`
+	Chains   []string `toml:"chains"`
+
+	BalanceThresholds
+}
+
+func (w SharedWallet) Validate() error {
+	if w.Address == "" && w.PubKey == "" {
+		return errors.New("either address or pubkey should be specified")
+	}
+
+	if w.Address != "" && w.PubKey != "" {
+		return errors.New("only one of address and pubkey should be specified")
+	}
+
+	if len(w.Chains) == 0 {
+		return errors.New("no chains provided")
+	}
+
+	return nil
+}
+
+// GetAddressBytes returns the address bytes to derive the chains addresses from,
+// either decoded from the address (bech32 or hex), or hashed from the pubkey.
+func (w SharedWallet) GetAddressBytes() ([]byte, error) {
+	if w.PubKey == "" {
+		if bech32.IsHexAddress(w.Address) {
+			return hex.DecodeString(w.Address[2:])
+		}
+
+		_, bytes, err := bech32.Decode(w.Address)
+		return bytes, err
+	}
+
+	pubKey, err := hex.DecodeString(strings.TrimPrefix(w.PubKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid pubkey: %s", err)
+	}
+
+	return bech32.PubKeyToAddress(pubKey)
+}
+
+// DeriveSharedWallets adds the shared wallets to all of their chains, with addresses
+// derived with each chain bech32 prefix. As the same mnemonic gives different keys
+// for different coin types, an address derived for a chain with a coin type other
+// than the wallet one is likely not controlled by the same key, so it's returned
+// as a warning, and deriving an address from a pubkey for an EVM chain is an error,
+// as EVM addresses are derived from the pubkey differently.
+func (c *Config) DeriveSharedWallets() ([]string, error) {
+	warnings := []string{}
+
+	for index, sharedWallet := range c.SharedWallets {
+		if err := sharedWallet.Validate(); err != nil {
+			return nil, fmt.Errorf("error in shared wallet %d: %s", index, err)
+		}
+
+		addressBytes, err := sharedWallet.GetAddressBytes()
+		if err != nil {
+			return nil, fmt.Errorf("error in shared wallet %d: %s", index, err)
+		}
+
+		for _, chainName := range sharedWallet.Chains {
+			chain, found := c.FindChainByName(chainName)
+			if !found {
+				return nil, fmt.Errorf("error in shared wallet %d: chain %s is not found", index, chainName)
+			}
+
+			if chain.Bech32Prefix == "" {
+				return nil, fmt.Errorf(
+					"error in shared wallet %d: chain %s has no bech32-prefix to derive the address with",
+					index,
+					chainName,
+				)
+			}
+
+			if sharedWallet.PubKey != "" && chain.CoinType == CoinTypeEthereum {
+				return nil, fmt.Errorf(
+					"error in shared wallet %d: cannot derive an address from pubkey for chain %s with coin type %d",
+					index,
+					chainName,
+					CoinTypeEthereum,
+				)
+			}
+
+			address, err := bech32.Encode(chain.Bech32Prefix, addressBytes)
+			if err != nil {
+				return nil, fmt.Errorf("error in shared wallet %d: %s", index, err)
+			}
+
+			if chain.CoinType != sharedWallet.CoinType {
+				warnings = append(warnings, fmt.Sprintf(
+					"shared wallet %d: address %s is derived for chain %s with coin type %d, "+
+						"while the wallet coin type is %d, so it's likely not controlled by the same key",
+					index,
+					address,
+					chainName,
+					chain.CoinType,
+					sharedWallet.CoinType,
+				))
+			}
+
+			if chain.HasWallet(address) {
+				continue
+			}
+
+			chain.Wallets = append(chain.Wallets, Wallet{
+				Address:           address,
+				Name:              sharedWallet.Name,
+				Group:             sharedWallet.Group,
+				BalanceThresholds: sharedWallet.BalanceThresholds,
+			})
+		}
+	}
+
+	return warnings, nil
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"main/pkg/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedWalletValidate(t *testing.T) {
+	t.Parallel()
+
+	require.ErrorContains(t, SharedWallet{}.Validate(), "either address or pubkey should be specified")
+	require.ErrorContains(
+		t,
+		SharedWallet{Address: "address", PubKey: "pubkey"}.Validate(),
+		"only one of address and pubkey should be specified",
+	)
+	require.ErrorContains(t, SharedWallet{Address: "address"}.Validate(), "no chains provided")
+	require.NoError(t, SharedWallet{Address: "address", Chains: []string{"chain"}}.Validate())
+}
+
+func TestSharedWalletGetAddressBytes(t *testing.T) {
+	t.Parallel()
+
+	bytes, err := SharedWallet{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}.GetAddressBytes()
+	require.NoError(t, err)
+	assert.Len(t, bytes, 20)
+
+	hexBytes, err := SharedWallet{Address: "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"}.GetAddressBytes()
+	require.NoError(t, err)
+	assert.Equal(t, bytes, hexBytes)
+
+	_, err = SharedWallet{Address: "invalid"}.GetAddressBytes()
+	require.Error(t, err)
+
+	_, err = SharedWallet{PubKey: "invalid"}.GetAddressBytes()
+	require.ErrorContains(t, err, "invalid pubkey")
+
+	pubKeyBytes, err := SharedWallet{
+		PubKey: "0x0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+	}.GetAddressBytes()
+	require.NoError(t, err)
+	assert.Len(t, pubKeyBytes, 20)
+}
+
+func TestDeriveSharedWalletsFromPubKey(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Chains: []Chain{
+			{Name: "cosmos", Bech32Prefix: "cosmos", CoinType: CoinTypeCosmos},
+			{Name: "osmosis", Bech32Prefix: "osmo", CoinType: CoinTypeCosmos},
+		},
+		SharedWallets: []SharedWallet{{
+			PubKey:   "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+			Name:     "operator",
+			Group:    "validator",
+			CoinType: CoinTypeCosmos,
+			Chains:   []string{"cosmos", "osmosis"},
+		}},
+	}
+
+	warnings, err := config.DeriveSharedWallets()
+	require.NoError(t, err)
+	assert.Empty(t, warnings)
+
+	assert.Equal(t, []Wallet{{
+		Address: "cosmos1w508d6qejxtdg4y5r3zarvary0c5xw7k6ah60c",
+		Name:    "operator",
+		Group:   "validator",
+	}}, config.Chains[0].Wallets)
+	assert.Equal(t, []Wallet{{
+		Address: "osmo1w508d6qejxtdg4y5r3zarvary0c5xw7kjxy2e2",
+		Name:    "operator",
+		Group:   "validator",
+	}}, config.Chains[1].Wallets)
+}
+
+func TestDeriveSharedWalletsErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name   string
+		wallet SharedWallet
+		err    string
+	}{
+		{"invalid", SharedWallet{}, "error in shared wallet 0: either address or pubkey"},
+		{"invalid address", SharedWallet{Address: "invalid", Chains: []string{"cosmos"}}, "error in shared wallet 0: "},
+		{
+			"chain not found",
+			SharedWallet{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7", Chains: []string{"unknown"}},
+			"chain unknown is not found",
+		},
+		{
+			"no prefix",
+			SharedWallet{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7", Chains: []string{"noprefix"}},
+			"chain noprefix has no bech32-prefix to derive the address with",
+		},
+		{
+			"pubkey for EVM chain",
+			SharedWallet{
+				PubKey: "0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+				Chains: []string{"evmos"},
+			},
+			"cannot derive an address from pubkey for chain evmos with coin type 60",
+		},
+	}
+
+	for _, testCase := range testCases {
+		config := &Config{
+			Chains: []Chain{
+				{Name: "cosmos", Bech32Prefix: "cosmos", CoinType: CoinTypeCosmos},
+				{Name: "evmos", Bech32Prefix: "evmos", CoinType: CoinTypeEthereum},
+				{Name: "noprefix", CoinType: CoinTypeCosmos},
+			},
+			SharedWallets: []SharedWallet{testCase.wallet},
+		}
+
+		_, err := config.DeriveSharedWallets()
+		require.ErrorContains(t, err, testCase.err, testCase.name)
+	}
+}
+
+func TestLoadConfigSharedWallets(t *testing.T) {
+	t.Parallel()
+
+	filesystem := &fs.TestFS{}
+	config, err := GetConfig("config-shared-wallets.toml", filesystem)
+	require.NoError(t, err)
+	require.NoError(t, config.Validate())
+
+	// already configured explicitly, so not added twice
+	assert.Equal(t, []Wallet{
+		{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7", Name: "existing"},
+	}, config.Chains[0].Wallets)
+	assert.Equal(t, []Wallet{
+		{Address: "osmo18g57flmd85h8arrm9edk5z323shx7xst4pkucv", Name: "operator", Group: "validator"},
+	}, config.Chains[1].Wallets)
+	assert.Equal(t, []Wallet{
+		{Address: "evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k", Name: "operator", Group: "validator"},
+	}, config.Chains[2].Wallets)
+
+	require.Len(t, config.Warnings, 1)
+	assert.Contains(t, config.Warnings[0], "derived for chain evmos with coin type 60, while the wallet coin type is 118")
+}
@@ -0,0 +1,140 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+const (
+	ThresholdLevelMin  = "min"
+	ThresholdLevelWarn = "warn"
+)
+
+// BalanceThresholds are the min and warn balances per denom (display denom, like "atom",
+// or base denom, like "uatom"), in tokens, for the wallets to be alerted on going below.
+type BalanceThresholds struct {
+	MinBalance  map[string]float64 `toml:"min-balance"`
+	WarnBalance map[string]float64 `toml:"warn-balance"`
+}
+
+// GroupConfig is the defaults for all the wallets with this group, across all chains.
+type GroupConfig struct {
+	BalanceThresholds
+}
+
+// BalanceThreshold is a single wallet threshold, with the wallet-level one
+// taking precedence over the wallet group one.
+type BalanceThreshold struct {
+	Denom string
+	Level string
+	Value float64
+}
+
+func (t BalanceThresholds) Validate() error {
+	for denom, value := range t.MinBalance {
+		if value < 0 {
+			return fmt.Errorf("min-balance for %s cannot be negative", denom)
+		}
+	}
+
+	for denom, value := range t.WarnBalance {
+		if value < 0 {
+			return fmt.Errorf("warn-balance for %s cannot be negative", denom)
+		}
+
+		if minBalance, found := t.MinBalance[denom]; found && value < minBalance {
+			return fmt.Errorf("warn-balance for %s cannot be lower than min-balance", denom)
+		}
+	}
+
+	return nil
+}
+
+func (c *Config) ValidateGroups() error {
+	for name, group := range c.Groups {
+		if name == "" {
+			return errors.New("empty group name")
+		}
+
+		if err := group.Validate(); err != nil {
+			return fmt.Errorf("error in group %s: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWalletThresholds validates the chain wallets thresholds merged with their groups ones,
+// as a wallet can override a group threshold so it's inconsistent with another group one,
+// like a min-balance higher than the group warn-balance.
+func (c *Config) ValidateWalletThresholds(chain Chain) error {
+	walletErrors := []error{}
+
+	for index, wallet := range chain.Wallets {
+		if err := c.GetWalletBalanceThresholds(wallet).Validate(); err != nil {
+			walletErrors = append(walletErrors, fmt.Errorf(
+				"error in wallet %d: %s (with group %s thresholds applied)",
+				index,
+				err,
+				wallet.Group,
+			))
+		}
+	}
+
+	return errors.Join(walletErrors...)
+}
+
+// GetWalletBalanceThresholds returns the wallet balance thresholds, with the ones from the
+// wallet group config used for the denoms and levels not set for the wallet itself.
+func (c *Config) GetWalletBalanceThresholds(wallet Wallet) BalanceThresholds {
+	group := c.Groups[wallet.Group]
+
+	return BalanceThresholds{
+		MinBalance:  mergeThresholds(group.MinBalance, wallet.MinBalance),
+		WarnBalance: mergeThresholds(group.WarnBalance, wallet.WarnBalance),
+	}
+}
+
+// GetWalletThresholds returns the wallet balance thresholds, with the ones from the
+// wallet group config used for the denoms and levels not set for the wallet itself,
+// sorted by denom and level.
+func (c *Config) GetWalletThresholds(wallet Wallet) []BalanceThreshold {
+	merged := c.GetWalletBalanceThresholds(wallet)
+	minBalances := merged.MinBalance
+	warnBalances := merged.WarnBalance
+
+	thresholds := make([]BalanceThreshold, 0, len(minBalances)+len(warnBalances))
+
+	for denom, value := range minBalances {
+		thresholds = append(thresholds, BalanceThreshold{Denom: denom, Level: ThresholdLevelMin, Value: value})
+	}
+
+	for denom, value := range warnBalances {
+		thresholds = append(thresholds, BalanceThreshold{Denom: denom, Level: ThresholdLevelWarn, Value: value})
+	}
+
+	sort.Slice(thresholds, func(i, j int) bool {
+		if thresholds[i].Denom != thresholds[j].Denom {
+			return thresholds[i].Denom < thresholds[j].Denom
+		}
+
+		return thresholds[i].Level < thresholds[j].Level
+	})
+
+	return thresholds
+}
+
+func mergeThresholds(defaults map[string]float64, overrides map[string]float64) map[string]float64 {
+	merged := make(map[string]float64, len(defaults)+len(overrides))
+
+	for denom, value := range defaults {
+		merged[denom] = value
+	}
+
+	for denom, value := range overrides {
+		merged[denom] = value
+	}
+
+	return merged
+}
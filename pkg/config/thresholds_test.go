@@ -0,0 +1,126 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/BurntSushi/toml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalanceThresholdsValidate(t *testing.T) {
+	t.Parallel()
+
+	require.NoError(t, BalanceThresholds{}.Validate())
+	require.NoError(t, BalanceThresholds{
+		MinBalance:  map[string]float64{"atom": 1},
+		WarnBalance: map[string]float64{"atom": 5, "osmo": 1},
+	}.Validate())
+
+	require.ErrorContains(
+		t,
+		BalanceThresholds{MinBalance: map[string]float64{"atom": -1}}.Validate(),
+		"min-balance for atom cannot be negative",
+	)
+	require.ErrorContains(
+		t,
+		BalanceThresholds{WarnBalance: map[string]float64{"atom": -1}}.Validate(),
+		"warn-balance for atom cannot be negative",
+	)
+	require.ErrorContains(
+		t,
+		BalanceThresholds{
+			MinBalance:  map[string]float64{"atom": 5},
+			WarnBalance: map[string]float64{"atom": 1},
+		}.Validate(),
+		"warn-balance for atom cannot be lower than min-balance",
+	)
+}
+
+func TestConfigValidateGroups(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Groups: map[string]GroupConfig{
+		"validator": {BalanceThresholds: BalanceThresholds{MinBalance: map[string]float64{"atom": -1}}},
+	}}
+	require.ErrorContains(t, config.ValidateGroups(), "error in group validator: min-balance for atom cannot be negative")
+
+	config.Groups["validator"] = GroupConfig{}
+	require.NoError(t, config.ValidateGroups())
+}
+
+func TestConfigGetWalletThresholds(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{Groups: map[string]GroupConfig{
+		"validator": {BalanceThresholds: BalanceThresholds{
+			MinBalance:  map[string]float64{"atom": 1, "osmo": 10},
+			WarnBalance: map[string]float64{"atom": 5},
+		}},
+	}}
+
+	assert.Empty(t, config.GetWalletThresholds(Wallet{Group: "other"}))
+
+	assert.Equal(t, []BalanceThreshold{
+		{Denom: "atom", Level: ThresholdLevelMin, Value: 2},
+		{Denom: "atom", Level: ThresholdLevelWarn, Value: 5},
+		{Denom: "osmo", Level: ThresholdLevelMin, Value: 10},
+	}, config.GetWalletThresholds(Wallet{
+		Group:             "validator",
+		BalanceThresholds: BalanceThresholds{MinBalance: map[string]float64{"atom": 2}},
+	}))
+}
+
+func TestConfigValidateMergedWalletThresholds(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{
+		Groups: map[string]GroupConfig{
+			"validator": {BalanceThresholds: BalanceThresholds{
+				MinBalance:  map[string]float64{"atom": 1},
+				WarnBalance: map[string]float64{"atom": 5},
+			}},
+		},
+		Chains: []Chain{{
+			Name:        "chain",
+			LCDEndpoint: "https://example.com",
+			Wallets: []Wallet{{
+				Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7",
+				Group:   "validator",
+				// valid on its own, but higher than the group warn-balance
+				BalanceThresholds: BalanceThresholds{MinBalance: map[string]float64{"atom": 10}},
+			}},
+		}},
+	}
+
+	require.ErrorContains(
+		t,
+		config.Validate(),
+		"error in chain 0 (chain): error in wallet 0: warn-balance for atom cannot be lower than min-balance "+
+			"(with group validator thresholds applied)",
+	)
+
+	config.Chains[0].Wallets[0].WarnBalance = map[string]float64{"atom": 20}
+	require.NoError(t, config.Validate())
+}
+
+func TestLoadConfigThresholds(t *testing.T) {
+	t.Parallel()
+
+	config := &Config{}
+	_, err := toml.Decode(`
+[groups.validator]
+min-balance = { atom = 1 }
+
+[[chains]]
+name = "chain"
+
+[[chains.wallets]]
+address = "address"
+warn-balance = { atom = 5.5 }
+`, config)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 1, config.Groups["validator"].MinBalance["atom"], 0.001)
+	assert.InDelta(t, 5.5, config.Chains[0].Wallets[0].WarnBalance["atom"], 0.001)
+}
@@ -2,6 +2,10 @@ package config
 
 import (
 	"errors"
+	"fmt"
+	"main/pkg/bech32"
+
+	"github.com/guregu/null/v5"
 )
 
 func (w Wallet) Validate() error {
@@ -9,11 +13,69 @@ func (w Wallet) Validate() error {
 		return errors.New("address for wallet is not specified")
 	}
 
+	if err := w.BalanceThresholds.Validate(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ValidateAddresses checks that the wallet address (and the validator address, if set)
+// is a valid bech32 address with the chain prefix, if it is known. EVM hex addresses
+// are allowed, as long as the prefix is known, so they can be converted to bech32.
+func (w Wallet) ValidateAddresses(bech32Prefix string) error {
+	if bech32.IsHexAddress(w.Address) {
+		if bech32Prefix == "" {
+			return errors.New("bech32-prefix is required for hex addresses")
+		}
+	} else if err := validateBech32Address(w.Address, bech32Prefix); err != nil {
+		return fmt.Errorf("invalid address %s: %s", w.Address, err)
+	}
+
+	if w.ValidatorAddress == "" {
+		return nil
+	}
+
+	validatorPrefix := ""
+	if bech32Prefix != "" {
+		validatorPrefix = bech32Prefix + "valoper"
+	}
+
+	if err := validateBech32Address(w.ValidatorAddress, validatorPrefix); err != nil {
+		return fmt.Errorf("invalid validator address %s: %s", w.ValidatorAddress, err)
+	}
+
+	return nil
+}
+
+func validateBech32Address(address string, expectedPrefix string) error {
+	prefix, _, err := bech32.Decode(address)
+	if err != nil {
+		return err
+	}
+
+	if expectedPrefix != "" && prefix != expectedPrefix {
+		return fmt.Errorf("expected prefix %s, got %s", expectedPrefix, prefix)
+	}
+
 	return nil
 }
 
 type Wallet struct {
-	Address string `toml:"address"`
-	Name    string `toml:"name"`
-	Group   string `toml:"group"`
+	Address          string `toml:"address"`
+	Name             string `toml:"name"`
+	Group            string `toml:"group"`
+	ValidatorAddress string `toml:"validator-address"`
+
+	QueryDelegations          null.Bool `toml:"query-delegations"`
+	QueryUnbondingDelegations null.Bool `toml:"query-unbonding-delegations"`
+	QueryRedelegations        null.Bool `toml:"query-redelegations"`
+	QueryRewards              null.Bool `toml:"query-rewards"`
+	QueryVesting              null.Bool `toml:"query-vesting"`
+
+	BalanceThresholds
+}
+
+func (w Wallet) IsValidator() bool {
+	return w.ValidatorAddress != ""
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -22,3 +23,47 @@ func TestWalletValid(t *testing.T) {
 	err := wallet.Validate()
 	require.NoError(t, err)
 }
+
+func TestWalletIsValidator(t *testing.T) {
+	t.Parallel()
+
+	assert.False(t, Wallet{Address: "wallet"}.IsValidator())
+	assert.True(t, Wallet{Address: "wallet", ValidatorAddress: "valoper"}.IsValidator())
+}
+
+func TestWalletValidateAddresses(t *testing.T) {
+	t.Parallel()
+
+	wallet := Wallet{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"}
+	require.NoError(t, wallet.ValidateAddresses("cosmos"))
+	require.NoError(t, wallet.ValidateAddresses(""))
+	require.ErrorContains(t, wallet.ValidateAddresses("osmo"), "expected prefix osmo, got cosmos")
+
+	wallet = Wallet{Address: "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw8"}
+	require.ErrorContains(t, wallet.ValidateAddresses("cosmos"), "invalid checksum")
+
+	wallet = Wallet{Address: "address"}
+	require.ErrorContains(t, wallet.ValidateAddresses(""), "invalid address address")
+
+	wallet = Wallet{Address: "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"}
+	require.NoError(t, wallet.ValidateAddresses("evmos"))
+	require.ErrorContains(t, wallet.ValidateAddresses(""), "bech32-prefix is required for hex addresses")
+}
+
+func TestWalletValidateValidatorAddress(t *testing.T) {
+	t.Parallel()
+
+	wallet := Wallet{
+		Address:          "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7",
+		ValidatorAddress: "cosmosvaloper18g57flmd85h8arrm9edk5z323shx7xstcw3ezd",
+	}
+	require.NoError(t, wallet.ValidateAddresses("cosmos"))
+	require.ErrorContains(t, wallet.ValidateAddresses("osmo"), "invalid address")
+
+	wallet.ValidatorAddress = "cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7"
+	require.ErrorContains(
+		t,
+		wallet.ValidateAddresses("cosmos"),
+		"invalid validator address cosmos18g57flmd85h8arrm9edk5z323shx7xsta69vw7: expected prefix cosmosvaloper, got cosmos",
+	)
+}
@@ -0,0 +1,114 @@
+package evm
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/http"
+	"main/pkg/types"
+	"math/big"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"cosmossdk.io/math"
+	"github.com/rs/zerolog"
+)
+
+// BalanceOfSelector is the ERC-20 balanceOf(address) function selector.
+const BalanceOfSelector = "0x70a08231"
+
+// MaxBalanceBitLength is the max balance size that can be represented as a decimal.
+// Bigger balances can only be returned by broken contracts.
+const MaxBalanceBitLength = 192
+
+// Client queries EVM balances via Ethereum JSON-RPC, which is exposed
+// by Cosmos EVM chains (like Evmos, Cronos or Kava) alongside the Cosmos APIs.
+type Client struct {
+	Client   *http.Client
+	Endpoint string
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+}
+
+func NewClient(chain config.Chain, logger zerolog.Logger, tracer trace.Tracer) *Client {
+	return &Client{
+		Client:   http.NewClient(logger, chain.Name, tracer),
+		Endpoint: chain.EVMRPCEndpoint,
+		Logger:   logger.With().Str("component", "evm").Str("chain", chain.Name).Logger(),
+		Tracer:   tracer,
+	}
+}
+
+// GetBalance returns the native token balance of a 0x-prefixed address.
+func (c *Client) GetBalance(address string, ctx context.Context) (math.LegacyDec, types.QueryInfo, error) {
+	return c.queryAmount("eth_getBalance", []interface{}{address, "latest"}, ctx)
+}
+
+// GetERC20Balance returns the ERC-20 token balance of a 0x-prefixed address.
+func (c *Client) GetERC20Balance(
+	contract string,
+	address string,
+	ctx context.Context,
+) (math.LegacyDec, types.QueryInfo, error) {
+	// balanceOf(address) call data is the selector followed by the address padded to 32 bytes
+	data := BalanceOfSelector + fmt.Sprintf("%064s", strings.TrimPrefix(strings.ToLower(address), "0x"))
+
+	return c.queryAmount("eth_call", []interface{}{
+		types.EVMCall{To: contract, Data: data},
+		"latest",
+	}, ctx)
+}
+
+func (c *Client) queryAmount(
+	method string,
+	params []interface{},
+	ctx context.Context,
+) (math.LegacyDec, types.QueryInfo, error) {
+	request := types.JSONRPCRequest{
+		JSONRPC: "2.0",
+		ID:      1,
+		Method:  method,
+		Params:  params,
+	}
+
+	var response *types.JSONRPCResponse
+	queryInfo, _, err := c.Client.Post(c.Endpoint, request, &response, types.HTTPPredicateAlwaysPass(), ctx)
+	queryInfo.Endpoint = c.Endpoint
+
+	if err != nil {
+		return math.LegacyDec{}, queryInfo, err
+	}
+
+	if response.Error != nil {
+		queryInfo.Success = false
+		return math.LegacyDec{}, queryInfo, response.Error
+	}
+
+	amount, err := ParseHexAmount(response.Result)
+	if err != nil {
+		queryInfo.Success = false
+		return math.LegacyDec{}, queryInfo, err
+	}
+
+	return amount, queryInfo, nil
+}
+
+// ParseHexAmount parses a 0x-prefixed hex number, as JSON-RPC returns amounts.
+func ParseHexAmount(value string) (math.LegacyDec, error) {
+	hexValue := strings.TrimPrefix(value, "0x")
+	if hexValue == "" {
+		return math.LegacyDec{}, fmt.Errorf("empty amount: %q", value)
+	}
+
+	amount, ok := new(big.Int).SetString(hexValue, 16)
+	if !ok {
+		return math.LegacyDec{}, fmt.Errorf("invalid amount: %q", value)
+	}
+
+	if amount.BitLen() > MaxBalanceBitLength {
+		return math.LegacyDec{}, fmt.Errorf("amount is too big: %q", value)
+	}
+
+	return math.LegacyNewDecFromBigInt(amount), nil
+}
@@ -0,0 +1,121 @@
+package evm
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient() *Client {
+	return NewClient(
+		configPkg.Chain{Name: "chain", EVMRPCEndpoint: "https://example.com"},
+		*loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetBalanceOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://example.com",
+		httpmock.BodyContainsString(`"method":"eth_getBalance","params":["0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b","latest"]`),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-balance.json")),
+	)
+
+	balance, queryInfo, err := newTestClient().GetBalance("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b", context.Background())
+	require.NoError(t, err)
+	assert.True(t, queryInfo.Success)
+	assert.Equal(t, "https://example.com", queryInfo.Endpoint)
+	assert.Equal(t, math.LegacyMustNewDecFromStr("1000000000000000000"), balance)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetERC20BalanceOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://example.com",
+		httpmock.BodyContainsString(
+			`"method":"eth_call","params":[{"to":"0xcontract","data":"0x70a08231`+
+				`0000000000000000000000003a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"},"latest"]`,
+		),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-erc20-balance.json")),
+	)
+
+	balance, queryInfo, err := newTestClient().GetERC20Balance(
+		"0xcontract",
+		"0x3A29E4FF6D3D2E7E8C7B2E5B6A0A2A8C2E6F1A0B",
+		context.Background(),
+	)
+	require.NoError(t, err)
+	assert.True(t, queryInfo.Success)
+	assert.Equal(t, math.LegacyNewDec(2500000), balance)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetBalanceQueryFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	_, queryInfo, err := newTestClient().GetBalance("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b", context.Background())
+	require.ErrorContains(t, err, "custom error")
+	assert.False(t, queryInfo.Success)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestGetBalanceJSONRPCError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"POST",
+		"https://example.com",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-error.json")),
+	)
+
+	_, queryInfo, err := newTestClient().GetBalance("0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b", context.Background())
+	require.ErrorContains(t, err, "JSON-RPC error -32000: execution reverted")
+	assert.False(t, queryInfo.Success)
+}
+
+func TestParseHexAmount(t *testing.T) {
+	t.Parallel()
+
+	amount, err := ParseHexAmount("0x0")
+	require.NoError(t, err)
+	assert.True(t, amount.IsZero())
+
+	amount, err = ParseHexAmount("0xff")
+	require.NoError(t, err)
+	assert.Equal(t, math.LegacyNewDec(255), amount)
+
+	_, err = ParseHexAmount("0x")
+	require.ErrorContains(t, err, "empty amount")
+
+	_, err = ParseHexAmount("0xzz")
+	require.ErrorContains(t, err, "invalid amount")
+
+	_, err = ParseHexAmount("0xffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff")
+	require.ErrorContains(t, err, "amount is too big")
+}
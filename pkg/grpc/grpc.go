@@ -0,0 +1,136 @@
+package grpc
+
+import (
+	"context"
+	"crypto/tls"
+	"main/pkg/config"
+	"main/pkg/constants"
+	"main/pkg/proto"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+// BlockHeightMetadataKey is the gRPC response metadata key with the block height
+// the response was generated at, the same as the header LCD returns it in.
+const BlockHeightMetadataKey = "x-cosmos-block-height"
+
+type Client struct {
+	Chain    string
+	Endpoint string
+	PageSize uint64
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+
+	Conn            *grpc.ClientConn
+	LastQueryHeight map[string]int64
+	Mutex           sync.Mutex
+}
+
+func NewClient(chain config.Chain, logger zerolog.Logger, tracer trace.Tracer) (*Client, error) {
+	transportCredentials := insecure.NewCredentials()
+	if chain.GRPCTLS {
+		transportCredentials = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+
+	conn, err := grpc.NewClient(
+		chain.GRPCEndpoint,
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithDefaultCallOptions(grpc.ForceCodec(proto.Codec{})),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		Chain:           chain.Name,
+		Endpoint:        chain.GRPCEndpoint,
+		PageSize:        chain.PageSize,
+		Logger:          logger.With().Str("component", "grpc").Str("chain", chain.Name).Logger(),
+		Tracer:          tracer,
+		Conn:            conn,
+		LastQueryHeight: make(map[string]int64),
+	}, nil
+}
+
+func (c *Client) GetWalletBalances(address string, ctx context.Context) (*types.BalanceResponse, types.QueryInfo, error) {
+	return proto.GetAllBalances(c.Invoke, address, c.PageSize, ctx)
+}
+
+// Invoke calls the gRPC method, checking that the returned block height is not older
+// than the one returned for the same address previously.
+func (c *Client) Invoke(
+	method string,
+	address string,
+	request proto.Message,
+	response proto.Message,
+	ctx context.Context,
+) (types.QueryInfo, error) {
+	childCtx, span := c.Tracer.Start(ctx, "gRPC request")
+	defer span.End()
+
+	queryInfo := types.QueryInfo{
+		Success:  false,
+		Chain:    c.Chain,
+		URL:      c.Endpoint + method,
+		Endpoint: c.Endpoint,
+	}
+
+	c.Mutex.Lock()
+	lastHeight := c.LastQueryHeight[address]
+	c.Mutex.Unlock()
+
+	c.Logger.Debug().Str("method", method).Msg("Doing a query...")
+
+	timeoutCtx, cancel := context.WithTimeout(childCtx, 10*time.Second)
+	defer cancel()
+
+	var responseMetadata metadata.MD
+
+	start := time.Now()
+	err := c.Conn.Invoke(timeoutCtx, method, request, response, grpc.Header(&responseMetadata))
+	queryInfo.Duration = time.Since(start)
+
+	if err != nil {
+		c.Logger.Warn().Str("method", method).Err(err).Msg("Query failed")
+		return queryInfo, err
+	}
+
+	c.Logger.Debug().
+		Str("method", method).
+		Dur("duration", queryInfo.Duration).
+		Msg("Query is finished")
+
+	// converting metadata to the headers LCD returns, so the same predicate can be used
+	header := http.Header{}
+	for _, value := range responseMetadata.Get(BlockHeightMetadataKey) {
+		header.Add(constants.HeaderBlockHeight, value)
+	}
+
+	predicate := types.HTTPPredicateCheckHeightAfter(lastHeight)
+	if err := predicate(&http.Response{Header: header}); err != nil {
+		return queryInfo, err
+	}
+
+	newLastHeight, _ := utils.GetBlockHeightFromHeader(header)
+	queryInfo.Height = newLastHeight
+
+	c.Mutex.Lock()
+	if newLastHeight > c.LastQueryHeight[address] {
+		c.LastQueryHeight[address] = newLastHeight
+	}
+	c.Mutex.Unlock()
+
+	queryInfo.Success = true
+	return queryInfo, nil
+}
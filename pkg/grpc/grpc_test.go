@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/proto"
+	"main/pkg/tracing"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// startTestServer starts a gRPC server returning the balances at the block heights
+// provided, one per each query.
+func startTestServer(t *testing.T, heights ...string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	queryIndex := 0
+
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(proto.Codec{}),
+		grpc.UnknownServiceHandler(func(srv interface{}, stream grpc.ServerStream) error {
+			method, _ := grpc.MethodFromServerStream(stream)
+			if method != proto.QueryAllBalancesMethod {
+				return errors.New("unknown method")
+			}
+
+			request := &proto.QueryAllBalancesRequest{}
+			if err := stream.RecvMsg(request); err != nil {
+				return err
+			}
+
+			if request.Address != "address" {
+				return errors.New("unknown address")
+			}
+
+			height := heights[queryIndex]
+			queryIndex++
+
+			if err := stream.SetHeader(metadata.Pairs(BlockHeightMetadataKey, height)); err != nil {
+				return err
+			}
+
+			return stream.SendMsg(&proto.QueryAllBalancesResponse{Balances: []proto.Coin{
+				{Denom: "uatom", Amount: "123456"},
+			}})
+		}),
+	)
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	return listener.Addr().String()
+}
+
+func TestGRPCGetWalletBalancesOk(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startTestServer(t, "100", "101")
+	client, err := NewClient(
+		config.Chain{Name: "chain", GRPCEndpoint: endpoint},
+		*loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+	require.NoError(t, err)
+
+	balances, queryInfo, err := client.GetWalletBalances("address", context.Background())
+	require.NoError(t, err)
+	assert.True(t, queryInfo.Success)
+	assert.Equal(t, "chain", queryInfo.Chain)
+	assert.Equal(t, endpoint, queryInfo.Endpoint)
+	require.Len(t, balances.Balances, 1)
+	assert.Equal(t, "uatom", balances.Balances[0].Denom)
+	assert.Equal(t, int64(123456), balances.Balances[0].Amount.TruncateInt64())
+	assert.Equal(t, int64(100), client.LastQueryHeight["address"])
+	assert.Equal(t, int64(100), queryInfo.Height)
+
+	_, queryInfo, err = client.GetWalletBalances("address", context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(101), queryInfo.Height)
+	assert.Equal(t, int64(101), client.LastQueryHeight["address"])
+}
+
+func TestGRPCGetWalletBalancesHeightRegression(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startTestServer(t, "100", "99")
+	client, err := NewClient(
+		config.Chain{Name: "chain", GRPCEndpoint: endpoint},
+		*loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+	require.NoError(t, err)
+
+	_, _, err = client.GetWalletBalances("address", context.Background())
+	require.NoError(t, err)
+
+	balances, queryInfo, err := client.GetWalletBalances("address", context.Background())
+	require.Error(t, err)
+	require.ErrorContains(t, err, "previous height (100) is bigger than the current height (99)")
+	assert.False(t, queryInfo.Success)
+	assert.Nil(t, balances)
+}
+
+func TestGRPCGetWalletBalancesFail(t *testing.T) {
+	t.Parallel()
+
+	endpoint := startTestServer(t)
+	client, err := NewClient(
+		config.Chain{Name: "chain", GRPCEndpoint: endpoint},
+		*loggerPkg.GetNopLogger(),
+		tracing.InitNoopTracer(),
+	)
+	require.NoError(t, err)
+
+	balances, queryInfo, err := client.GetWalletBalances("unknown", context.Background())
+	require.Error(t, err)
+	assert.False(t, queryInfo.Success)
+	assert.Nil(t, balances)
+}
@@ -0,0 +1,98 @@
+package history
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/state"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+// Recorder stores the wallets balances into the history after each chain poll,
+// deleting the records older than the retention period once per cleanup interval.
+type Recorder struct {
+	Config *config.Config
+	State  *state.State
+	Store  *Store
+	Logger zerolog.Logger
+	Tracer trace.Tracer
+
+	LastCleanup time.Time
+	Mutex       sync.Mutex
+
+	// Now returns the current time, overridden in tests.
+	Now func() time.Time
+}
+
+func NewRecorder(
+	appConfig *config.Config,
+	appState *state.State,
+	store *Store,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *Recorder {
+	return &Recorder{
+		Config: appConfig,
+		State:  appState,
+		Store:  store,
+		Logger: logger.With().Str("component", "history_recorder").Logger(),
+		Tracer: tracer,
+		Now:    time.Now,
+	}
+}
+
+func (r *Recorder) OnChainQueried(ctx context.Context, chain config.Chain) {
+	_, span := r.Tracer.Start(ctx, "Recording balances history")
+	span.SetAttributes(attribute.String("chain", chain.Name))
+	defer span.End()
+
+	for _, wallet := range chain.Wallets {
+		entry, found := r.State.GetWalletEntry(chain.Name, wallet.Address)
+
+		// a failed query keeps the previous balances in the state, which are recorded already
+		if !found || !entry.Success {
+			continue
+		}
+
+		if err := r.Store.Record(chain.Name, wallet.Address, NewRecord(entry)); err != nil {
+			r.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("address", wallet.Address).
+				Msg("Could not record balances history")
+		}
+	}
+
+	r.cleanup()
+}
+
+func (r *Recorder) cleanup() {
+	retention := r.Config.HistoryConfig.Retention
+	if retention == 0 {
+		return
+	}
+
+	now := r.Now()
+
+	// the listener is called for each chain concurrently, so only one of them does the cleanup
+	r.Mutex.Lock()
+	if now.Sub(r.LastCleanup) < r.Config.HistoryConfig.CleanupInterval {
+		r.Mutex.Unlock()
+		return
+	}
+	r.LastCleanup = now
+	r.Mutex.Unlock()
+
+	deleted, err := r.Store.Cleanup(now.Add(-retention))
+	if err != nil {
+		r.Logger.Error().Err(err).Msg("Could not clean up balances history")
+		return
+	}
+
+	r.Logger.Debug().Int("deleted", deleted).Msg("Cleaned up balances history")
+}
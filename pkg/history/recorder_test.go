@@ -0,0 +1,122 @@
+package history
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecorderRecordsSuccessfulQueries(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{
+			{Address: "success"},
+			{Address: "failed"},
+			{Address: "not-fetched"},
+		},
+	}}}
+
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Success:   true,
+		Wallet:    config.Chains[0].Wallets[0],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(1000)}},
+		UpdatedAt: updatedAt,
+		Height:    123,
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Success:   false,
+		Wallet:    config.Chains[0].Wallets[1],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(2000)}},
+		UpdatedAt: updatedAt,
+	})
+
+	store := getTestStore(t)
+	recorder := NewRecorder(config, state, store, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	recorder.OnChainQueried(context.Background(), config.Chains[0])
+
+	records, err := store.Query("chain", "success", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(123), records[0].Height)
+	assert.True(t, updatedAt.Equal(records[0].Timestamp))
+	assert.Equal(t, []Balance{{Denom: "uatom", Amount: "1000"}}, records[0].Balances)
+
+	for _, address := range []string{"failed", "not-fetched"} {
+		records, err = store.Query("chain", address, time.Time{}, time.Time{})
+		require.NoError(t, err)
+		assert.Empty(t, records, address)
+	}
+}
+
+func TestRecorderCleanup(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{
+		HistoryConfig: configPkg.HistoryConfig{Retention: 24 * time.Hour, CleanupInterval: time.Hour},
+		Chains:        []configPkg.Chain{{Name: "chain"}},
+	}
+
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	store := getTestStore(t)
+	recorder := NewRecorder(config, statePkg.NewState(), store, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	recorder.Now = func() time.Time { return now }
+
+	require.NoError(t, store.Record("chain", "address", getTestRecord(now.Add(-48*time.Hour), 1, "1000")))
+	require.NoError(t, store.Record("chain", "address", getTestRecord(now.Add(-time.Hour), 2, "1000")))
+
+	recorder.OnChainQueried(context.Background(), config.Chains[0])
+
+	records, err := store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(2), records[0].Height)
+
+	// the cleanup interval hasn't passed yet, so the outdated record is kept
+	now = now.Add(30 * time.Minute)
+	require.NoError(t, store.Record("chain", "address", getTestRecord(now.Add(-48*time.Hour), 3, "1000")))
+	recorder.OnChainQueried(context.Background(), config.Chains[0])
+
+	records, err = store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	now = now.Add(30 * time.Minute)
+	recorder.OnChainQueried(context.Background(), config.Chains[0])
+
+	records, err = store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
+
+func TestRecorderKeepsForeverWithoutRetention(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{Name: "chain"}}}
+
+	store := getTestStore(t)
+	recorder := NewRecorder(config, statePkg.NewState(), store, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	require.NoError(t, store.Record("chain", "address", getTestRecord(time.Unix(1, 0), 1, "1000")))
+	recorder.OnChainQueried(context.Background(), config.Chains[0])
+
+	records, err := store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+}
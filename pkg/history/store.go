@@ -0,0 +1,196 @@
+package history
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"time"
+
+	"cosmossdk.io/math"
+	"go.etcd.io/bbolt"
+)
+
+// balancesBucket is the root bucket, containing a bucket per chain, each containing
+// a bucket per address, with the records keyed by their timestamps, so they are
+// stored in the chronological order and can be queried by a time range.
+var balancesBucket = []byte("balances")
+
+// Balance is a single recorded balance, with the exact amount in base units as a string.
+type Balance struct {
+	Denom  string `json:"denom"`
+	Amount string `json:"amount"`
+}
+
+func (b Balance) ToBalance() (types.Balance, error) {
+	amount, err := math.LegacyNewDecFromStr(b.Amount)
+	if err != nil {
+		return types.Balance{}, err
+	}
+
+	return types.Balance{Denom: b.Denom, Amount: amount}, nil
+}
+
+// Record is a successful wallet balances query result.
+type Record struct {
+	Timestamp time.Time `json:"timestamp"`
+	Height    int64     `json:"height"`
+	Balances  []Balance `json:"balances"`
+}
+
+func NewRecord(entry types.WalletBalanceEntry) Record {
+	record := Record{
+		Timestamp: entry.UpdatedAt,
+		Height:    entry.Height,
+		Balances:  make([]Balance, 0, len(entry.Balances)),
+	}
+
+	for _, balance := range entry.Balances {
+		if balance.Amount.IsNil() {
+			continue
+		}
+
+		record.Balances = append(record.Balances, Balance{
+			Denom:  balance.Denom,
+			Amount: utils.FormatAmount(balance.Amount),
+		})
+	}
+
+	return record
+}
+
+// Store keeps the wallets balances history in a BoltDB file.
+type Store struct {
+	DB *bbolt.DB
+}
+
+func NewStore(path string) (*Store, error) {
+	// the timeout is needed to not hang forever if the file is locked by another process
+	db, err := bbolt.Open(path, 0o600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(balancesBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{DB: db}, nil
+}
+
+func (s *Store) Close() error {
+	return s.DB.Close()
+}
+
+func (s *Store) Record(chain string, address string, record Record) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return s.DB.Update(func(tx *bbolt.Tx) error {
+		chainBucket, err := tx.Bucket(balancesBucket).CreateBucketIfNotExists([]byte(chain))
+		if err != nil {
+			return err
+		}
+
+		addressBucket, err := chainBucket.CreateBucketIfNotExists([]byte(address))
+		if err != nil {
+			return err
+		}
+
+		return addressBucket.Put(encodeTime(record.Timestamp), value)
+	})
+}
+
+// Query returns the wallet records between from and to, both inclusive, in the chronological
+// order. Zero from or to means the range is not limited from that side.
+func (s *Store) Query(chain string, address string, from time.Time, to time.Time) ([]Record, error) {
+	records := []Record{}
+
+	err := s.DB.View(func(tx *bbolt.Tx) error {
+		chainBucket := tx.Bucket(balancesBucket).Bucket([]byte(chain))
+		if chainBucket == nil {
+			return nil
+		}
+
+		addressBucket := chainBucket.Bucket([]byte(address))
+		if addressBucket == nil {
+			return nil
+		}
+
+		cursor := addressBucket.Cursor()
+
+		for key, value := cursor.Seek(encodeTime(from)); key != nil; key, value = cursor.Next() {
+			var record Record
+			if err := json.Unmarshal(value, &record); err != nil {
+				return err
+			}
+
+			if !to.IsZero() && record.Timestamp.After(to) {
+				break
+			}
+
+			records = append(records, record)
+		}
+
+		return nil
+	})
+
+	return records, err
+}
+
+// Cleanup deletes all the records older than before, returning how many were deleted.
+func (s *Store) Cleanup(before time.Time) (int, error) {
+	deleted := 0
+	beforeKey := encodeTime(before)
+
+	err := s.DB.Update(func(tx *bbolt.Tx) error {
+		rootBucket := tx.Bucket(balancesBucket)
+
+		return rootBucket.ForEachBucket(func(chain []byte) error {
+			chainBucket := rootBucket.Bucket(chain)
+
+			return chainBucket.ForEachBucket(func(address []byte) error {
+				addressBucket := chainBucket.Bucket(address)
+
+				// collecting the keys first, as deleting while iterating with a cursor skips keys
+				keys := [][]byte{}
+				cursor := addressBucket.Cursor()
+
+				for key, _ := cursor.First(); key != nil && bytes.Compare(key, beforeKey) < 0; key, _ = cursor.Next() {
+					keys = append(keys, bytes.Clone(key))
+				}
+
+				for _, key := range keys {
+					if err := addressBucket.Delete(key); err != nil {
+						return err
+					}
+				}
+
+				deleted += len(keys)
+				return nil
+			})
+		})
+	})
+
+	return deleted, err
+}
+
+// encodeTime returns the big-endian Unix nanoseconds timestamp, so the keys are sorted
+// chronologically byte-wise. Times before the Unix epoch, including the zero one, are
+// encoded as the epoch itself.
+func encodeTime(t time.Time) []byte {
+	key := make([]byte, 8)
+
+	if t.After(time.Unix(0, 0)) {
+		binary.BigEndian.PutUint64(key, uint64(t.UnixNano()))
+	}
+
+	return key
+}
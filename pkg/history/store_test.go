@@ -0,0 +1,162 @@
+package history
+
+import (
+	"main/pkg/types"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	store, err := NewStore(filepath.Join(t.TempDir(), "history.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_ = store.Close()
+	})
+
+	return store
+}
+
+func getTestRecord(timestamp time.Time, height int64, amount string) Record {
+	return Record{
+		Timestamp: timestamp,
+		Height:    height,
+		Balances:  []Balance{{Denom: "uatom", Amount: amount}},
+	}
+}
+
+func TestNewStoreInvalidPath(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewStore(filepath.Join(t.TempDir(), "not-existing", "history.db"))
+	require.Error(t, err)
+}
+
+func TestNewRecord(t *testing.T) {
+	t.Parallel()
+
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	record := NewRecord(types.WalletBalanceEntry{
+		UpdatedAt: updatedAt,
+		Height:    123,
+		Balances: types.Balances{
+			{Denom: "uatom", Amount: math.LegacyMustNewDecFromStr("123456789012345678901234.5")},
+			{Denom: "nil"},
+		},
+	})
+
+	assert.Equal(t, Record{
+		Timestamp: updatedAt,
+		Height:    123,
+		Balances:  []Balance{{Denom: "uatom", Amount: "123456789012345678901234.5"}},
+	}, record)
+
+	balance, err := record.Balances[0].ToBalance()
+	require.NoError(t, err)
+	assert.Equal(t, "uatom", balance.Denom)
+	assert.True(t, math.LegacyMustNewDecFromStr("123456789012345678901234.5").Equal(balance.Amount))
+
+	_, err = Balance{Denom: "uatom", Amount: "invalid"}.ToBalance()
+	require.Error(t, err)
+}
+
+func TestStoreRecordAndQuery(t *testing.T) {
+	t.Parallel()
+
+	store := getTestStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for index := 0; index < 5; index++ {
+		record := getTestRecord(start.Add(time.Duration(index)*time.Hour), int64(100+index), "1000")
+		require.NoError(t, store.Record("chain", "address", record))
+	}
+
+	require.NoError(t, store.Record("chain", "other", getTestRecord(start, 100, "2000")))
+	require.NoError(t, store.Record("other", "address", getTestRecord(start, 100, "3000")))
+
+	records, err := store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 5)
+
+	for index, record := range records {
+		assert.Equal(t, int64(100+index), record.Height)
+		assert.True(t, start.Add(time.Duration(index)*time.Hour).Equal(record.Timestamp))
+		assert.Equal(t, []Balance{{Denom: "uatom", Amount: "1000"}}, record.Balances)
+	}
+
+	records, err = store.Query("chain", "address", start.Add(time.Hour), start.Add(3*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, int64(101), records[0].Height)
+	assert.Equal(t, int64(103), records[2].Height)
+
+	records, err = store.Query("chain", "other", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "2000", records[0].Balances[0].Amount)
+
+	records, err = store.Query("chain", "not-existing", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	records, err = store.Query("not-existing", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func TestStoreCleanup(t *testing.T) {
+	t.Parallel()
+
+	store := getTestStore(t)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for index := 0; index < 5; index++ {
+		record := getTestRecord(start.Add(time.Duration(index)*time.Hour), int64(100+index), "1000")
+		require.NoError(t, store.Record("chain", "address", record))
+		require.NoError(t, store.Record("other", "address", record))
+	}
+
+	deleted, err := store.Cleanup(start.Add(2 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 4, deleted)
+
+	for _, chain := range []string{"chain", "other"} {
+		records, err := store.Query(chain, "address", time.Time{}, time.Time{})
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+		assert.Equal(t, int64(102), records[0].Height)
+	}
+
+	deleted, err = store.Cleanup(start)
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+}
+
+func TestStorePersistsAfterReopen(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "history.db")
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	store, err := NewStore(path)
+	require.NoError(t, err)
+	require.NoError(t, store.Record("chain", "address", getTestRecord(timestamp, 100, "1000")))
+	require.NoError(t, store.Close())
+
+	store, err = NewStore(path)
+	require.NoError(t, err)
+	defer store.Close()
+
+	records, err := store.Query("chain", "address", time.Time{}, time.Time{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, int64(100), records[0].Height)
+}
@@ -1,10 +1,14 @@
 package http
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"io"
 	"main/pkg/types"
 	"net/http"
+	neturl "net/url"
 	"time"
 
 	"go.opentelemetry.io/otel/trace"
@@ -14,9 +18,10 @@ import (
 )
 
 type Client struct {
-	logger zerolog.Logger
-	chain  string
-	tracer trace.Tracer
+	logger     zerolog.Logger
+	chain      string
+	tracer     trace.Tracer
+	redactURLs bool
 }
 
 func NewClient(logger zerolog.Logger, chain string, tracer trace.Tracer) *Client {
@@ -27,11 +32,105 @@ func NewClient(logger zerolog.Logger, chain string, tracer trace.Tracer) *Client
 	}
 }
 
+// NewRedactingClient creates a client for the URLs containing secrets, like Telegram bot tokens
+// or Slack webhooks, which logs them and returns them in query info and errors redacted.
+func NewRedactingClient(logger zerolog.Logger, tracer trace.Tracer) *Client {
+	client := NewClient(logger, "", tracer)
+	client.redactURLs = true
+	return client
+}
+
+// RedactURL returns the URL with only its scheme and host, as its path and query may contain secrets.
+func RedactURL(url string) string {
+	parsed, err := neturl.Parse(url)
+	if err != nil || parsed.Host == "" {
+		return "<redacted>"
+	}
+
+	return parsed.Scheme + "://" + parsed.Host + "/<redacted>"
+}
+
+func (c *Client) getLoggedURL(url string) string {
+	if !c.redactURLs {
+		return url
+	}
+
+	return RedactURL(url)
+}
+
+// redactError replaces the URL in the error returned by the request, if URLs should be redacted.
+func (c *Client) redactError(err error) error {
+	if !c.redactURLs {
+		return err
+	}
+
+	var urlErr *neturl.Error
+	if errors.As(err, &urlErr) {
+		return &neturl.Error{Op: urlErr.Op, URL: RedactURL(urlErr.URL), Err: urlErr.Err}
+	}
+
+	return err
+}
+
 func (c *Client) Get(
 	url string,
 	target interface{},
 	predicate types.HTTPPredicate,
 	ctx context.Context,
+) (types.QueryInfo, http.Header, error) {
+	return c.GetWithHeaders(url, target, predicate, map[string]string{}, ctx)
+}
+
+func (c *Client) GetWithHeaders(
+	url string,
+	target interface{},
+	predicate types.HTTPPredicate,
+	headers map[string]string,
+	ctx context.Context,
+) (types.QueryInfo, http.Header, error) {
+	return c.doRequest(http.MethodGet, url, nil, target, predicate, headers, ctx)
+}
+
+// Post does a POST request with the JSON-encoded body.
+func (c *Client) Post(
+	url string,
+	body interface{},
+	target interface{},
+	predicate types.HTTPPredicate,
+	ctx context.Context,
+) (types.QueryInfo, http.Header, error) {
+	return c.PostWithHeaders(url, body, target, predicate, map[string]string{}, ctx)
+}
+
+func (c *Client) PostWithHeaders(
+	url string,
+	body interface{},
+	target interface{},
+	predicate types.HTTPPredicate,
+	headers map[string]string,
+	ctx context.Context,
+) (types.QueryInfo, http.Header, error) {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return types.QueryInfo{Chain: c.chain, URL: c.getLoggedURL(url)}, nil, err
+	}
+
+	requestHeaders := map[string]string{"Content-Type": "application/json"}
+	for key, value := range headers {
+		requestHeaders[key] = value
+	}
+
+	return c.doRequest(http.MethodPost, url, bytes.NewReader(bodyBytes), target, predicate, requestHeaders, ctx)
+}
+
+func (c *Client) doRequest(
+	method string,
+	url string,
+	body io.Reader,
+	target interface{},
+	predicate types.HTTPPredicate,
+	headers map[string]string,
+	ctx context.Context,
 ) (types.QueryInfo, http.Header, error) {
 	childCtx, span := c.tracer.Start(ctx, "HTTP request")
 	defer span.End()
@@ -45,37 +144,49 @@ func (c *Client) Get(
 		transport = http.DefaultTransport
 	}
 
+	// otelhttp records the full URL in span attributes, so it's not used for secret URLs
+	if !c.redactURLs {
+		transport = otelhttp.NewTransport(transport)
+	}
+
 	client := &http.Client{
 		Timeout:   10 * 1000000000,
-		Transport: otelhttp.NewTransport(transport),
+		Transport: transport,
 	}
 	start := time.Now()
 
+	loggedURL := c.getLoggedURL(url)
+
 	queryInfo := types.QueryInfo{
 		Success: false,
 		Chain:   c.chain,
-		URL:     url,
+		URL:     loggedURL,
 	}
 
-	req, err := http.NewRequestWithContext(childCtx, http.MethodGet, url, nil)
+	req, err := http.NewRequestWithContext(childCtx, method, url, body)
 	if err != nil {
-		return queryInfo, nil, err
+		return queryInfo, nil, c.redactError(err)
 	}
 
 	req.Header.Set("User-Agent", "cosmos-wallets-exporter")
 
-	c.logger.Debug().Str("url", url).Msg("Doing a query...")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	c.logger.Debug().Str("url", loggedURL).Msg("Doing a query...")
 
 	res, err := client.Do(req)
 	queryInfo.Duration = time.Since(start)
 	if err != nil {
-		c.logger.Warn().Str("url", url).Err(err).Msg("Query failed")
+		err = c.redactError(err)
+		c.logger.Warn().Str("url", loggedURL).Err(err).Msg("Query failed")
 		return queryInfo, nil, err
 	}
 	defer res.Body.Close()
 
 	c.logger.Debug().
-		Str("url", url).
+		Str("url", loggedURL).
 		Dur("duration", time.Since(start)).
 		Msg("Query is finished")
 
@@ -83,6 +194,12 @@ func (c *Client) Get(
 		return queryInfo, res.Header, predicateErr
 	}
 
+	// some APIs (like Slack webhooks) return a plain text response, which is not needed
+	if target == nil {
+		queryInfo.Success = true
+		return queryInfo, res.Header, nil
+	}
+
 	err = json.NewDecoder(res.Body).Decode(target)
 	queryInfo.Success = err == nil
 
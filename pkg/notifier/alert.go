@@ -0,0 +1,69 @@
+package notifier
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	AlertStatusFiring   = "firing"
+	AlertStatusResolved = "resolved"
+)
+
+// Alert is a wallet balance going below (firing) or back above (resolved) its threshold.
+type Alert struct {
+	Status    string    `json:"status"`
+	Chain     string    `json:"chain"`
+	Address   string    `json:"address"`
+	Name      string    `json:"name"`
+	Group     string    `json:"group"`
+	Denom     string    `json:"denom"`
+	Level     string    `json:"level"`
+	Amount    float64   `json:"amount"`
+	Threshold float64   `json:"threshold"`
+	StartsAt  time.Time `json:"starts_at"`
+}
+
+// GetKey returns the alert identity, the same for the firing and resolved notifications.
+func (a Alert) GetKey() string {
+	return a.Chain + "/" + a.Address + "/" + a.Denom + "/" + a.Level
+}
+
+func (a Alert) GetWalletName() string {
+	if a.Name != "" {
+		return a.Name
+	}
+
+	return a.Address
+}
+
+// GetMessage returns the alert as a human-readable text, for chat notifications.
+func (a Alert) GetMessage() string {
+	amount := strconv.FormatFloat(a.Amount, 'f', -1, 64)
+	threshold := strconv.FormatFloat(a.Threshold, 'f', -1, 64)
+
+	if a.Status == AlertStatusResolved {
+		return fmt.Sprintf(
+			"✅ Resolved: %s on %s has %s %s, back above %s balance of %s %s",
+			a.GetWalletName(),
+			a.Chain,
+			amount,
+			a.Denom,
+			a.Level,
+			threshold,
+			a.Denom,
+		)
+	}
+
+	return fmt.Sprintf(
+		"🔴 Firing: %s on %s has %s %s, below %s balance of %s %s",
+		a.GetWalletName(),
+		a.Chain,
+		amount,
+		a.Denom,
+		a.Level,
+		threshold,
+		a.Denom,
+	)
+}
@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/http"
+	"main/pkg/types"
+	"strings"
+)
+
+// Channel is where the alerts notifications are sent to.
+type Channel interface {
+	Name() string
+	Send(alert Alert, ctx context.Context) error
+}
+
+// WebhookChannel sends the alerts as JSON to a generic HTTP webhook.
+type WebhookChannel struct {
+	Client *http.Client
+	Config config.WebhookConfig
+}
+
+func (c *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+func (c *WebhookChannel) Send(alert Alert, ctx context.Context) error {
+	_, _, err := c.Client.PostWithHeaders(
+		c.Config.URL,
+		alert,
+		nil,
+		types.HTTPPredicateCheckStatusOK(),
+		c.Config.Headers,
+		ctx,
+	)
+	return err
+}
+
+// TelegramChannel sends the alerts as messages via the Telegram Bot API.
+type TelegramChannel struct {
+	Client *http.Client
+	Config config.TelegramConfig
+}
+
+type TelegramMessage struct {
+	ChatID string `json:"chat_id"`
+	Text   string `json:"text"`
+}
+
+func (c *TelegramChannel) Name() string {
+	return "telegram"
+}
+
+func (c *TelegramChannel) Send(alert Alert, ctx context.Context) error {
+	url := strings.TrimSuffix(c.Config.APIURL, "/") + "/bot" + c.Config.BotToken + "/sendMessage"
+
+	_, _, err := c.Client.Post(
+		url,
+		TelegramMessage{ChatID: c.Config.ChatID, Text: alert.GetMessage()},
+		nil,
+		types.HTTPPredicateCheckStatusOK(),
+		ctx,
+	)
+	return err
+}
+
+// SlackChannel sends the alerts to a Slack-compatible incoming webhook.
+type SlackChannel struct {
+	Client *http.Client
+	Config config.SlackConfig
+}
+
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+func (c *SlackChannel) Name() string {
+	return "slack"
+}
+
+func (c *SlackChannel) Send(alert Alert, ctx context.Context) error {
+	_, _, err := c.Client.Post(
+		c.Config.WebhookURL,
+		SlackMessage{Text: alert.GetMessage()},
+		nil,
+		types.HTTPPredicateCheckStatusOK(),
+		ctx,
+	)
+	return err
+}
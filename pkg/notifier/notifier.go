@@ -0,0 +1,220 @@
+package notifier
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/http"
+	queriersPkg "main/pkg/queriers"
+	"main/pkg/state"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+// AlertState is a currently firing alert, kept to deduplicate notifications.
+type AlertState struct {
+	Alert Alert
+	// LastSentAt is when the firing notification was sent last time,
+	// zero if it wasn't sent yet, as it was silenced or all the channels failed.
+	LastSentAt time.Time
+}
+
+// Notifier evaluates the wallets balance thresholds after each chain poll and sends
+// notifications on alerts starting firing or getting resolved. A firing alert is only
+// sent again after the repeat interval, and nothing is sent for silenced alerts.
+type Notifier struct {
+	Config   *config.Config
+	State    *state.State
+	Channels []Channel
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+
+	Alerts map[string]*AlertState
+	Mutex  sync.Mutex
+
+	// Now returns the current time, overridden in tests.
+	Now func() time.Time
+}
+
+func NewNotifier(
+	appConfig *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *Notifier {
+	// Telegram bot tokens and Slack webhook URLs are secrets, so they should not get into logs
+	client := http.NewRedactingClient(logger, tracer)
+	notifierConfig := appConfig.NotifierConfig
+
+	channels := []Channel{}
+
+	for _, webhook := range notifierConfig.Webhooks {
+		channels = append(channels, &WebhookChannel{Client: client, Config: webhook})
+	}
+
+	for _, telegram := range notifierConfig.Telegram {
+		channels = append(channels, &TelegramChannel{Client: client, Config: telegram})
+	}
+
+	for _, slack := range notifierConfig.Slack {
+		channels = append(channels, &SlackChannel{Client: client, Config: slack})
+	}
+
+	return &Notifier{
+		Config:   appConfig,
+		State:    appState,
+		Channels: channels,
+		Logger:   logger.With().Str("component", "notifier").Logger(),
+		Tracer:   tracer,
+		Alerts:   make(map[string]*AlertState),
+		Now:      time.Now,
+	}
+}
+
+func (n *Notifier) OnChainQueried(ctx context.Context, chain config.Chain) {
+	childCtx, span := n.Tracer.Start(ctx, "Evaluating thresholds")
+	span.SetAttributes(attribute.String("chain", chain.Name))
+	defer span.End()
+
+	for _, wallet := range chain.Wallets {
+		n.evaluateWallet(childCtx, chain, wallet)
+	}
+}
+
+func (n *Notifier) evaluateWallet(ctx context.Context, chain config.Chain, wallet config.Wallet) {
+	thresholds := n.Config.GetWalletThresholds(wallet)
+	if len(thresholds) == 0 {
+		return
+	}
+
+	entry, found := n.State.GetWalletEntry(chain.Name, wallet.Address)
+	if !found || entry.UpdatedAt.IsZero() {
+		return
+	}
+
+	for _, threshold := range thresholds {
+		amount, err := queriersPkg.GetWalletDenomAmount(n.Config, n.State, chain, entry, threshold.Denom)
+		if err != nil {
+			n.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("address", wallet.Address).
+				Str("denom", threshold.Denom).
+				Msg("Could not get wallet amount to compare with the threshold")
+			continue
+		}
+
+		n.processAlert(ctx, Alert{
+			Status:    AlertStatusFiring,
+			Chain:     chain.Name,
+			Address:   wallet.Address,
+			Name:      wallet.Name,
+			Group:     wallet.Group,
+			Denom:     threshold.Denom,
+			Level:     threshold.Level,
+			Amount:    amount,
+			Threshold: threshold.Value,
+		}, wallet, amount < threshold.Value)
+	}
+}
+
+func (n *Notifier) processAlert(ctx context.Context, alert Alert, wallet config.Wallet, firing bool) {
+	now := n.Now()
+	key := alert.GetKey()
+
+	n.Mutex.Lock()
+	alertState, wasFiring := n.Alerts[key]
+	n.Mutex.Unlock()
+
+	silenced := n.isSilenced(alert, wallet, now)
+
+	if !firing {
+		if !wasFiring {
+			return
+		}
+
+		n.Mutex.Lock()
+		delete(n.Alerts, key)
+		n.Mutex.Unlock()
+
+		// no need to resolve what was never sent
+		if alertState.LastSentAt.IsZero() || silenced || !n.Config.NotifierConfig.SendResolved.Bool {
+			return
+		}
+
+		alert.Status = AlertStatusResolved
+		alert.StartsAt = alertState.Alert.StartsAt
+		n.send(ctx, alert)
+		return
+	}
+
+	if !wasFiring {
+		alert.StartsAt = now
+		alertState = &AlertState{Alert: alert}
+
+		n.Mutex.Lock()
+		n.Alerts[key] = alertState
+		n.Mutex.Unlock()
+	} else {
+		alert.StartsAt = alertState.Alert.StartsAt
+	}
+
+	if silenced {
+		return
+	}
+
+	if !alertState.LastSentAt.IsZero() {
+		repeatInterval := n.Config.NotifierConfig.RepeatInterval
+		if repeatInterval == 0 || now.Sub(alertState.LastSentAt) < repeatInterval {
+			return
+		}
+	}
+
+	if n.send(ctx, alert) {
+		n.Mutex.Lock()
+		alertState.Alert = alert
+		alertState.LastSentAt = now
+		n.Mutex.Unlock()
+	}
+}
+
+func (n *Notifier) isSilenced(alert Alert, wallet config.Wallet, now time.Time) bool {
+	for _, silence := range n.Config.NotifierConfig.Silences {
+		if silence.IsActive(now) && silence.Matches(alert.Chain, wallet, alert.Denom) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// send sends the alert to all channels, returning whether it was sent to any of them,
+// so if all of them failed, it'll be retried after the next poll.
+func (n *Notifier) send(ctx context.Context, alert Alert) bool {
+	sent := false
+
+	for _, channel := range n.Channels {
+		if err := channel.Send(alert, ctx); err != nil {
+			n.Logger.Error().
+				Err(err).
+				Str("channel", channel.Name()).
+				Str("alert", alert.GetKey()).
+				Str("status", alert.Status).
+				Msg("Could not send alert notification")
+			continue
+		}
+
+		n.Logger.Info().
+			Str("channel", channel.Name()).
+			Str("alert", alert.GetKey()).
+			Str("status", alert.Status).
+			Msg("Sent alert notification")
+		sent = true
+	}
+
+	return sent
+}
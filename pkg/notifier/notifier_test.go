@@ -0,0 +1,333 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	Path    string
+	Headers http.Header
+	Body    map[string]interface{}
+}
+
+// testServer is a local stand-in for webhooks, Telegram and Slack APIs,
+// recording all the requests it gets.
+type testServer struct {
+	Server     *httptest.Server
+	Requests   []receivedRequest
+	StatusCode int
+	Mutex      sync.Mutex
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+
+	server := &testServer{StatusCode: http.StatusOK}
+	server.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bytes, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		var body map[string]interface{}
+		require.NoError(t, json.Unmarshal(bytes, &body))
+
+		server.Mutex.Lock()
+		defer server.Mutex.Unlock()
+
+		server.Requests = append(server.Requests, receivedRequest{Path: r.URL.Path, Headers: r.Header, Body: body})
+		w.WriteHeader(server.StatusCode)
+		_, _ = w.Write([]byte("ok"))
+	}))
+
+	t.Cleanup(server.Server.Close)
+	return server
+}
+
+func (s *testServer) PopRequests() []receivedRequest {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	requests := s.Requests
+	s.Requests = nil
+	return requests
+}
+
+func getTestNotifier(
+	t *testing.T,
+	notifierConfig configPkg.NotifierConfig,
+	amount int64,
+) (*Notifier, *statePkg.State, *time.Time) {
+	t.Helper()
+
+	config := &configPkg.Config{
+		NotifierConfig: notifierConfig,
+		Chains: []configPkg.Chain{{
+			Name: "chain",
+			Wallets: []configPkg.Wallet{{
+				Address: "address",
+				Name:    "name",
+				Group:   "group",
+				BalanceThresholds: configPkg.BalanceThresholds{
+					MinBalance: map[string]float64{"atom": 1},
+				},
+			}},
+			Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+		}},
+	}
+
+	state := statePkg.NewState()
+	setBalance(state, config, amount)
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notifier := NewNotifier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	notifier.Now = func() time.Time { return now }
+
+	return notifier, state, &now
+}
+
+func setBalance(state *statePkg.State, config *configPkg.Config, amount int64) {
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Success:   true,
+		Wallet:    config.Chains[0].Wallets[0],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(amount)}},
+		UpdatedAt: time.Now(),
+	})
+}
+
+func TestNotifierChannels(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, _, _ := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:      true,
+		SendResolved: null.BoolFrom(true),
+		Webhooks: []configPkg.WebhookConfig{{
+			URL:     server.Server.URL + "/webhook",
+			Headers: map[string]string{"Authorization": "Bearer token"},
+		}},
+		Telegram: []configPkg.TelegramConfig{{BotToken: "token", ChatID: "123", APIURL: server.Server.URL}},
+		Slack:    []configPkg.SlackConfig{{WebhookURL: server.Server.URL + "/slack"}},
+	}, 500000)
+
+	notifier.OnChainQueried(context.Background(), notifier.Config.Chains[0])
+
+	requests := server.PopRequests()
+	require.Len(t, requests, 3)
+
+	assert.Equal(t, "/webhook", requests[0].Path)
+	assert.Equal(t, "Bearer token", requests[0].Headers.Get("Authorization"))
+	assert.Equal(t, "application/json", requests[0].Headers.Get("Content-Type"))
+	assert.Equal(t, map[string]interface{}{
+		"status":    "firing",
+		"chain":     "chain",
+		"address":   "address",
+		"name":      "name",
+		"group":     "group",
+		"denom":     "atom",
+		"level":     "min",
+		"amount":    0.5,
+		"threshold": float64(1),
+		"starts_at": "2024-01-01T00:00:00Z",
+	}, requests[0].Body)
+
+	message := "🔴 Firing: name on chain has 0.5 atom, below min balance of 1 atom"
+
+	assert.Equal(t, "/bottoken/sendMessage", requests[1].Path)
+	assert.Equal(t, map[string]interface{}{"chat_id": "123", "text": message}, requests[1].Body)
+
+	assert.Equal(t, "/slack", requests[2].Path)
+	assert.Equal(t, map[string]interface{}{"text": message}, requests[2].Body)
+}
+
+func TestNotifierDedupRepeatAndResolve(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, state, now := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:        true,
+		RepeatInterval: time.Hour,
+		SendResolved:   null.BoolFrom(true),
+		Slack:          []configPkg.SlackConfig{{WebhookURL: server.Server.URL}},
+	}, 500000)
+
+	chain := notifier.Config.Chains[0]
+
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	// still firing, but the repeat interval hasn't passed yet
+	*now = now.Add(30 * time.Minute)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+
+	*now = now.Add(30 * time.Minute)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	setBalance(state, notifier.Config, 2000000)
+	notifier.OnChainQueried(context.Background(), chain)
+
+	requests := server.PopRequests()
+	require.Len(t, requests, 1)
+	assert.Equal(t, map[string]interface{}{
+		"text": "✅ Resolved: name on chain has 2 atom, back above min balance of 1 atom",
+	}, requests[0].Body)
+
+	// nothing to resolve anymore
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+	assert.Empty(t, notifier.Alerts)
+}
+
+func TestNotifierNoRepeatAndNoResolved(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, state, now := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:      true,
+		SendResolved: null.BoolFrom(false),
+		Slack:        []configPkg.SlackConfig{{WebhookURL: server.Server.URL}},
+	}, 500000)
+
+	chain := notifier.Config.Chains[0]
+
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	*now = now.Add(24 * time.Hour)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+
+	setBalance(state, notifier.Config, 2000000)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+}
+
+func TestNotifierSilence(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, state, now := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:      true,
+		SendResolved: null.BoolFrom(true),
+		Slack:        []configPkg.SlackConfig{{WebhookURL: server.Server.URL}},
+		Silences: []configPkg.SilenceConfig{
+			{
+				Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+				Group: "group",
+			},
+			{
+				Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+				Chain: "other",
+			},
+		},
+	}, 500000)
+
+	chain := notifier.Config.Chains[0]
+
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+
+	// firing alert is sent once the silence is over
+	*now = now.Add(time.Hour)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	setBalance(state, notifier.Config, 2000000)
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+}
+
+func TestNotifierSilencedAlertIsNotResolved(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, state, _ := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:      true,
+		SendResolved: null.BoolFrom(true),
+		Slack:        []configPkg.SlackConfig{{WebhookURL: server.Server.URL}},
+		Silences: []configPkg.SilenceConfig{{
+			Start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			End:   time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC),
+		}},
+	}, 500000)
+
+	chain := notifier.Config.Chains[0]
+
+	notifier.OnChainQueried(context.Background(), chain)
+	setBalance(state, notifier.Config, 2000000)
+	notifier.OnChainQueried(context.Background(), chain)
+
+	require.Empty(t, server.PopRequests())
+	assert.Empty(t, notifier.Alerts)
+}
+
+func TestNotifierRetriesFailedSend(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+	server.StatusCode = http.StatusInternalServerError
+
+	notifier, _, _ := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled:        true,
+		RepeatInterval: time.Hour,
+		SendResolved:   null.BoolFrom(true),
+		Webhooks:       []configPkg.WebhookConfig{{URL: server.Server.URL}},
+	}, 500000)
+
+	chain := notifier.Config.Chains[0]
+
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	server.Mutex.Lock()
+	server.StatusCode = http.StatusOK
+	server.Mutex.Unlock()
+
+	// the previous notification failed, so it's sent again without waiting for the repeat interval
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Len(t, server.PopRequests(), 1)
+
+	notifier.OnChainQueried(context.Background(), chain)
+	require.Empty(t, server.PopRequests())
+}
+
+func TestNotifierSkipsNotFetchedWallets(t *testing.T) {
+	t.Parallel()
+
+	server := newTestServer(t)
+
+	notifier, state, _ := getTestNotifier(t, configPkg.NotifierConfig{
+		Enabled: true,
+		Slack:   []configPkg.SlackConfig{{WebhookURL: server.Server.URL}},
+	}, 500000)
+
+	state.SetWalletEntry(types.WalletBalanceEntry{Chain: "chain", Wallet: notifier.Config.Chains[0].Wallets[0]})
+
+	notifier.OnChainQueried(context.Background(), notifier.Config.Chains[0])
+	require.Empty(t, server.PopRequests())
+}
@@ -0,0 +1,173 @@
+package prices
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/types"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+// Cache wraps a price provider, only querying it for the prices that were not
+// requested from it within TTL, and serving the last known prices that are not older
+// than max staleness if the underlying provider fails.
+// Prices the provider didn't return are not requested again until TTL passes either,
+// so a denom without a price doesn't make each scrape query the provider.
+type Cache struct {
+	Provider types.PriceProvider
+	Config   config.PriceCacheConfig
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+
+	Prices types.Prices
+	// CheckedAt is when each price was last requested from the provider, whether it was returned or not.
+	CheckedAt map[types.PriceKey]time.Time
+	Mutex     sync.Mutex
+}
+
+func NewCache(
+	provider types.PriceProvider,
+	cacheConfig config.PriceCacheConfig,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *Cache {
+	return &Cache{
+		Provider:  provider,
+		Config:    cacheConfig,
+		Logger:    logger.With().Str("component", "prices_cache").Logger(),
+		Tracer:    tracer,
+		Prices:    types.Prices{},
+		CheckedAt: make(map[types.PriceKey]time.Time),
+	}
+}
+
+func (c *Cache) Name() string {
+	return "cache"
+}
+
+func (c *Cache) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	childCtx, span := c.Tracer.Start(ctx, "Querying cached prices")
+	defer span.End()
+
+	// the lock is not held while fetching, so a slow provider doesn't block other callers;
+	// the expired prices are marked as checked before that, so they are only fetched once
+	c.Mutex.Lock()
+	now := time.Now()
+	expiredDenoms := c.getExpiredDenoms(denoms, currencies, now)
+	freshPrices := c.getCachedPrices(denoms, currencies, now, c.Config.TTL)
+	cachedPrices := c.getCachedPrices(denoms, currencies, now, c.Config.MaxStaleness)
+	c.Mutex.Unlock()
+
+	if len(expiredDenoms) == 0 {
+		return cachedPrices, []types.QueryInfo{}, nil
+	}
+
+	var (
+		fetchedPrices types.Prices
+		queryInfos    []types.QueryInfo
+		err           error
+	)
+
+	// the fresh cached prices are passed along, as derived prices may be calculated from them
+	if derivedProvider, ok := c.Provider.(types.DerivedPriceProvider); ok {
+		fetchedPrices, queryInfos, err = derivedProvider.GetDerivedPrices(expiredDenoms, currencies, freshPrices, childCtx)
+	} else {
+		fetchedPrices, queryInfos, err = c.Provider.GetPrices(expiredDenoms, currencies, childCtx)
+	}
+
+	if err != nil {
+		c.Logger.Warn().Err(err).Msg("Error fetching prices, using cached ones")
+	}
+
+	c.Mutex.Lock()
+	defer c.Mutex.Unlock()
+
+	for key, price := range fetchedPrices {
+		if price.UpdatedAt.IsZero() {
+			price.UpdatedAt = now
+		}
+
+		c.Prices[key] = price
+	}
+
+	prices := c.getCachedPrices(denoms, currencies, now, c.Config.MaxStaleness)
+
+	if len(prices) == 0 && err != nil {
+		return nil, queryInfos, err
+	}
+
+	return prices, queryInfos, nil
+}
+
+// getExpiredDenoms returns the denoms that have any price not requested from the provider
+// within TTL, marking all their prices as checked now.
+// Should be called with the cache mutex held.
+func (c *Cache) getExpiredDenoms(
+	denoms []types.ChainDenom,
+	currencies []string,
+	now time.Time,
+) []types.ChainDenom {
+	expiredDenoms := []types.ChainDenom{}
+
+	for _, denom := range denoms {
+		expired := false
+
+		for _, currency := range currencies {
+			key := types.PriceKey{Chain: denom.Chain, Denom: denom.DenomInfo.Denom, Currency: currency}
+			if checkedAt, found := c.CheckedAt[key]; !found || now.Sub(checkedAt) >= c.Config.TTL {
+				expired = true
+				break
+			}
+		}
+
+		if !expired {
+			continue
+		}
+
+		expiredDenoms = append(expiredDenoms, denom)
+
+		for _, currency := range currencies {
+			c.CheckedAt[types.PriceKey{Chain: denom.Chain, Denom: denom.DenomInfo.Denom, Currency: currency}] = now
+		}
+	}
+
+	return expiredDenoms
+}
+
+// getCachedPrices returns the cached prices of the denoms that were fetched within the max age,
+// or within TTL, as a price not older than TTL is always served.
+// Should be called with the cache mutex held.
+func (c *Cache) getCachedPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	now time.Time,
+	maxAge time.Duration,
+) types.Prices {
+	prices := types.Prices{}
+
+	for _, denom := range denoms {
+		for _, currency := range currencies {
+			price, found := c.Prices.Get(denom.Chain, denom.DenomInfo.Denom, currency)
+			if !found {
+				continue
+			}
+
+			age := now.Sub(price.UpdatedAt)
+			if age >= c.Config.TTL && age > maxAge {
+				continue
+			}
+
+			prices.Set(denom.Chain, denom.DenomInfo.Denom, currency, price)
+		}
+	}
+
+	return prices
+}
@@ -0,0 +1,238 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPriceProvider struct {
+	Prices types.Prices
+	Error  error
+	Calls  int
+}
+
+func (p *testPriceProvider) Name() string {
+	return "test"
+}
+
+func (p *testPriceProvider) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	p.Calls++
+	return p.Prices, []types.QueryInfo{}, p.Error
+}
+
+func getCacheTestDenoms() []types.ChainDenom {
+	return []types.ChainDenom{{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom"}}}
+}
+
+func TestCacheServesFreshPrices(t *testing.T) {
+	t.Parallel()
+
+	provider := &testPriceProvider{Prices: types.Prices{
+		{Chain: "chain", Denom: "uatom", Currency: "usd"}: {Value: 10, Source: "test"},
+	}}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	assert.Equal(t, "cache", cache.Name())
+
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, 1, provider.Calls)
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 10, price.Value, 0.001)
+	assert.False(t, price.UpdatedAt.IsZero())
+
+	prices, _, err = cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, prices, 1)
+	assert.Equal(t, 1, provider.Calls)
+}
+
+func TestCacheServesStalePrices(t *testing.T) {
+	t.Parallel()
+
+	provider := &testPriceProvider{Error: errors.New("custom error")}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	updatedAt := time.Now().Add(-10 * time.Minute)
+	cache.Prices.Set("chain", "uatom", "usd", types.Price{Value: 10, Source: "test", UpdatedAt: updatedAt})
+
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.Calls)
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.Equal(t, updatedAt, price.UpdatedAt)
+}
+
+func TestCacheDropsTooStalePrices(t *testing.T) {
+	t.Parallel()
+
+	provider := &testPriceProvider{Error: errors.New("custom error")}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	cache.Prices.Set("chain", "uatom", "usd", types.Price{
+		Value:     10,
+		Source:    "test",
+		UpdatedAt: time.Now().Add(-2 * time.Hour),
+	})
+
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.Error(t, err)
+	assert.Empty(t, prices)
+}
+
+func TestCacheRefreshesExpiredPrices(t *testing.T) {
+	t.Parallel()
+
+	provider := &testPriceProvider{Prices: types.Prices{
+		{Chain: "chain", Denom: "uatom", Currency: "usd"}: {Value: 20, Source: "test"},
+	}}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	cache.Prices.Set("chain", "uatom", "usd", types.Price{
+		Value:     10,
+		Source:    "test",
+		UpdatedAt: time.Now().Add(-10 * time.Minute),
+	})
+
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, provider.Calls)
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 20, price.Value, 0.001)
+}
+
+func TestCacheCachesMissingPrices(t *testing.T) {
+	t.Parallel()
+
+	// the price is returned for usd only, so eur is missing
+	provider := &testPriceProvider{Prices: types.Prices{
+		{Chain: "chain", Denom: "uatom", Currency: "usd"}: {Value: 10, Source: "test"},
+	}}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	for i := 0; i < 3; i++ {
+		prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd", "eur"}, context.Background())
+		require.NoError(t, err)
+		assert.Len(t, prices, 1)
+	}
+
+	// the missing price is not requested again until TTL passes
+	assert.Equal(t, 1, provider.Calls)
+
+	cache.CheckedAt[types.PriceKey{Chain: "chain", Denom: "uatom", Currency: "eur"}] = time.Now().Add(-time.Minute)
+
+	_, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd", "eur"}, context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, provider.Calls)
+}
+
+func TestCacheCachesFailures(t *testing.T) {
+	t.Parallel()
+
+	provider := &testPriceProvider{Error: errors.New("custom error")}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	_, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.Error(t, err)
+
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Equal(t, 1, provider.Calls)
+}
+
+type blockingPriceProvider struct {
+	Started chan struct{}
+	Release chan struct{}
+}
+
+func (p *blockingPriceProvider) Name() string {
+	return "blocking"
+}
+
+func (p *blockingPriceProvider) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	close(p.Started)
+	<-p.Release
+
+	return types.Prices{
+		{Chain: "chain", Denom: "uatom", Currency: "usd"}: {Value: 20, Source: "blocking"},
+	}, []types.QueryInfo{}, nil
+}
+
+func TestCacheDoesNotBlockWhileFetching(t *testing.T) {
+	t.Parallel()
+
+	provider := &blockingPriceProvider{Started: make(chan struct{}), Release: make(chan struct{})}
+	cache := NewCache(provider, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	cache.Prices.Set("chain", "uatom", "usd", types.Price{
+		Value:     10,
+		Source:    "test",
+		UpdatedAt: time.Now().Add(-10 * time.Minute),
+	})
+
+	done := make(chan types.Prices)
+	go func() {
+		prices, _, _ := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+		done <- prices
+	}()
+
+	<-provider.Started
+
+	// while the price is being fetched, other callers get the cached one without waiting
+	prices, _, err := cache.GetPrices(getCacheTestDenoms(), []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 10, price.Value, 0.001)
+
+	close(provider.Release)
+
+	price, found = (<-done).Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 20, price.Value, 0.001)
+}
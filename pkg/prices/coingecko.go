@@ -0,0 +1,100 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/http"
+	"main/pkg/types"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+type CoingeckoResponse map[string]map[string]float64
+
+type Coingecko struct {
+	Client *http.Client
+	Config config.CoingeckoConfig
+	Logger zerolog.Logger
+	Tracer trace.Tracer
+}
+
+func NewCoingecko(appConfig config.CoingeckoConfig, logger zerolog.Logger, tracer trace.Tracer) *Coingecko {
+	return &Coingecko{
+		Config: appConfig,
+		Client: http.NewClient(logger, config.PriceProviderCoingecko, tracer),
+		Logger: logger.With().Str("component", "coingecko").Logger(),
+		Tracer: tracer,
+	}
+}
+
+func (c *Coingecko) Name() string {
+	return config.PriceProviderCoingecko
+}
+
+func (c *Coingecko) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	childCtx, span := c.Tracer.Start(ctx, "Querying Coingecko prices")
+	defer span.End()
+
+	ids := []string{}
+	idsMap := map[string]bool{}
+
+	for _, denom := range denoms {
+		if denom.DenomInfo.CoingeckoCurrency == "" || idsMap[denom.DenomInfo.CoingeckoCurrency] {
+			continue
+		}
+
+		ids = append(ids, denom.DenomInfo.CoingeckoCurrency)
+		idsMap[denom.DenomInfo.CoingeckoCurrency] = true
+	}
+
+	prices := types.Prices{}
+
+	if len(ids) == 0 {
+		return prices, []types.QueryInfo{}, nil
+	}
+
+	url := fmt.Sprintf(
+		"%s/simple/price?ids=%s&vs_currencies=%s",
+		c.Config.GetBaseURL(),
+		strings.Join(ids, ","),
+		strings.Join(currencies, ","),
+	)
+
+	headers := map[string]string{}
+	if c.Config.APIKey != "" {
+		headers[fmt.Sprintf("x-cg-%s-api-key", c.Config.APIKeyType)] = c.Config.APIKey
+	}
+
+	var response CoingeckoResponse
+	queryInfo, _, err := c.Client.GetWithHeaders(url, &response, types.HTTPPredicateAlwaysPass(), headers, childCtx)
+	if err != nil {
+		c.Logger.Error().Err(err).Msg("Could not get rate")
+		return nil, []types.QueryInfo{queryInfo}, err
+	}
+
+	for _, denom := range denoms {
+		denomPrices, ok := response[denom.DenomInfo.CoingeckoCurrency]
+		if !ok {
+			continue
+		}
+
+		for _, currency := range currencies {
+			if price, ok := denomPrices[currency]; ok {
+				prices.Set(denom.Chain, denom.DenomInfo.Denom, currency, types.Price{
+					Value:  price,
+					Source: c.Name(),
+				})
+			}
+		}
+	}
+
+	return prices, []types.QueryInfo{queryInfo}, nil
+}
@@ -0,0 +1,113 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoingeckoNoDenoms(t *testing.T) {
+	t.Parallel()
+
+	coingecko := NewCoingecko(configPkg.CoingeckoConfig{}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	assert.Equal(t, "coingecko", coingecko.Name())
+
+	prices, queryInfos, err := coingecko.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom"}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Empty(t, queryInfos)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoingeckoFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos&vs_currencies=usd",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	coingecko := NewCoingecko(configPkg.CoingeckoConfig{}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := coingecko.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoingeckoCurrency: "cosmos"}},
+	}, []string{"usd"}, context.Background())
+	require.Error(t, err)
+	assert.Nil(t, prices)
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoingeckoProOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"GET",
+		"https://pro-api.coingecko.com/api/v3/simple/price?ids=cosmos,sentinel,unknown&vs_currencies=usd,eur",
+		httpmock.HeaderIs("x-cg-pro-api-key", "key"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coingecko-multiple.json")),
+	)
+
+	coingecko := NewCoingecko(configPkg.CoingeckoConfig{
+		APIKey:     "key",
+		APIKeyType: configPkg.CoingeckoAPIKeyTypePro,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := coingecko.GetPrices([]types.ChainDenom{
+		{Chain: "chain1", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoingeckoCurrency: "cosmos"}},
+		{Chain: "chain2", DenomInfo: configPkg.DenomInfo{Denom: "ibc/atom", CoingeckoCurrency: "cosmos"}},
+		{Chain: "chain2", DenomInfo: configPkg.DenomInfo{Denom: "udvpn", CoingeckoCurrency: "sentinel"}},
+		{Chain: "chain2", DenomInfo: configPkg.DenomInfo{Denom: "unknown", CoingeckoCurrency: "unknown"}},
+	}, []string{"usd", "eur"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.True(t, queryInfos[0].Success)
+	assert.Len(t, prices, 6)
+
+	price, found := prices.Get("chain2", "ibc/atom", "eur")
+	assert.True(t, found)
+	assert.InDelta(t, 5.4, price.Value, 0.001)
+	assert.Equal(t, "coingecko", price.Source)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoingeckoDemoOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos&vs_currencies=usd",
+		httpmock.HeaderIs("x-cg-demo-api-key", "key"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coingecko.json")),
+	)
+
+	coingecko := NewCoingecko(configPkg.CoingeckoConfig{
+		APIKey:     "key",
+		APIKeyType: configPkg.CoingeckoAPIKeyTypeDemo,
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, _, err := coingecko.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoingeckoCurrency: "cosmos"}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 5.84, price.Value, 0.001)
+}
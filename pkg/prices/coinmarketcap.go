@@ -0,0 +1,119 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/http"
+	"main/pkg/types"
+	"strings"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+type CoinMarketCapQuote struct {
+	Price float64 `json:"price"`
+}
+
+type CoinMarketCapCurrency struct {
+	Quote map[string]CoinMarketCapQuote `json:"quote"`
+}
+
+type CoinMarketCapResponse struct {
+	Data map[string]CoinMarketCapCurrency `json:"data"`
+}
+
+type CoinMarketCap struct {
+	Client *http.Client
+	Config config.CoinMarketCapConfig
+	Logger zerolog.Logger
+	Tracer trace.Tracer
+}
+
+func NewCoinMarketCap(appConfig config.CoinMarketCapConfig, logger zerolog.Logger, tracer trace.Tracer) *CoinMarketCap {
+	return &CoinMarketCap{
+		Config: appConfig,
+		Client: http.NewClient(logger, config.PriceProviderCoinMarketCap, tracer),
+		Logger: logger.With().Str("component", "coinmarketcap").Logger(),
+		Tracer: tracer,
+	}
+}
+
+func (c *CoinMarketCap) Name() string {
+	return config.PriceProviderCoinMarketCap
+}
+
+func (c *CoinMarketCap) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	childCtx, span := c.Tracer.Start(ctx, "Querying CoinMarketCap prices")
+	defer span.End()
+
+	ids := []string{}
+	idsMap := map[string]bool{}
+
+	for _, denom := range denoms {
+		if denom.DenomInfo.CoinMarketCapCurrency == "" || idsMap[denom.DenomInfo.CoinMarketCapCurrency] {
+			continue
+		}
+
+		ids = append(ids, denom.DenomInfo.CoinMarketCapCurrency)
+		idsMap[denom.DenomInfo.CoinMarketCapCurrency] = true
+	}
+
+	prices := types.Prices{}
+
+	if len(ids) == 0 {
+		return prices, []types.QueryInfo{}, nil
+	}
+
+	headers := map[string]string{"X-CMC_PRO_API_KEY": c.Config.APIKey}
+	queryInfos := []types.QueryInfo{}
+
+	var lastError error
+
+	// the basic plan allows only one convert currency per request,
+	// so each currency is fetched separately and a failure of one doesn't affect the others
+	for _, currency := range currencies {
+		url := fmt.Sprintf(
+			"%s/v2/cryptocurrency/quotes/latest?id=%s&convert=%s",
+			c.Config.BaseURL,
+			strings.Join(ids, ","),
+			strings.ToUpper(currency),
+		)
+
+		var response CoinMarketCapResponse
+		queryInfo, _, err := c.Client.GetWithHeaders(url, &response, types.HTTPPredicateAlwaysPass(), headers, childCtx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			c.Logger.Error().Err(err).Str("currency", currency).Msg("Could not get rate")
+			lastError = err
+			continue
+		}
+
+		for _, denom := range denoms {
+			currencyData, ok := response.Data[denom.DenomInfo.CoinMarketCapCurrency]
+			if !ok {
+				continue
+			}
+
+			if quote, ok := currencyData.Quote[strings.ToUpper(currency)]; ok {
+				prices.Set(denom.Chain, denom.DenomInfo.Denom, currency, types.Price{
+					Value:  quote.Price,
+					Source: c.Name(),
+				})
+			}
+		}
+	}
+
+	if len(prices) == 0 && lastError != nil {
+		return nil, queryInfos, lastError
+	}
+
+	return prices, queryInfos, nil
+}
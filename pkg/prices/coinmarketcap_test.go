@@ -0,0 +1,131 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinMarketCapNoDenoms(t *testing.T) {
+	t.Parallel()
+
+	coinMarketCap := NewCoinMarketCap(configPkg.CoinMarketCapConfig{}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	assert.Equal(t, "coinmarketcap", coinMarketCap.Name())
+
+	prices, queryInfos, err := coinMarketCap.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom"}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Empty(t, queryInfos)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoinMarketCapFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794&convert=USD",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	coinMarketCap := NewCoinMarketCap(configPkg.CoinMarketCapConfig{
+		APIKey:  "key",
+		BaseURL: "https://example.com",
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := coinMarketCap.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoinMarketCapCurrency: "3794"}},
+	}, []string{"usd"}, context.Background())
+	require.Error(t, err)
+	assert.Nil(t, prices)
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoinMarketCapOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterMatcherResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794,1234&convert=USD",
+		httpmock.HeaderIs("X-CMC_PRO_API_KEY", "key"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coinmarketcap.json")),
+	)
+	httpmock.RegisterMatcherResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794,1234&convert=EUR",
+		httpmock.HeaderIs("X-CMC_PRO_API_KEY", "key"),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coinmarketcap-eur.json")),
+	)
+
+	coinMarketCap := NewCoinMarketCap(configPkg.CoinMarketCapConfig{
+		APIKey:  "key",
+		BaseURL: "https://example.com",
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := coinMarketCap.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoinMarketCapCurrency: "3794"}},
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "unknown", CoinMarketCapCurrency: "1234"}},
+	}, []string{"usd", "eur"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+	assert.Len(t, prices, 2)
+
+	price, found := prices.Get("chain", "uatom", "eur")
+	assert.True(t, found)
+	assert.InDelta(t, 5.4, price.Value, 0.001)
+	assert.Equal(t, "coinmarketcap", price.Source)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestCoinMarketCapOneCurrencyFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794&convert=USD",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coinmarketcap.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794&convert=EUR",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	coinMarketCap := NewCoinMarketCap(configPkg.CoinMarketCapConfig{
+		APIKey:  "key",
+		BaseURL: "https://example.com",
+	}, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := coinMarketCap.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoinMarketCapCurrency: "3794"}},
+	}, []string{"usd", "eur"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.False(t, queryInfos[1].Success)
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 5.84, price.Value, 0.001)
+
+	_, found = prices.Get("chain", "uatom", "eur")
+	assert.False(t, found)
+}
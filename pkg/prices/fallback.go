@@ -0,0 +1,118 @@
+package prices
+
+import (
+	"context"
+	"main/pkg/types"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+// Fallback queries the price providers one by one, in the order they are
+// specified, each next one only for the denoms the previous ones did not return
+// prices for in all currencies.
+type Fallback struct {
+	Providers []types.PriceProvider
+	Logger    zerolog.Logger
+	Tracer    trace.Tracer
+}
+
+func NewFallback(providers []types.PriceProvider, logger zerolog.Logger, tracer trace.Tracer) *Fallback {
+	return &Fallback{
+		Providers: providers,
+		Logger:    logger.With().Str("component", "prices_fallback").Logger(),
+		Tracer:    tracer,
+	}
+}
+
+func (f *Fallback) Name() string {
+	return "fallback"
+}
+
+func (f *Fallback) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	return f.GetDerivedPrices(denoms, currencies, types.Prices{}, ctx)
+}
+
+// GetDerivedPrices queries the providers the same way as GetPrices, passing the known prices
+// (like the cached ones) to the derived price providers along with the ones fetched by the
+// previous providers, so a derived price can be calculated even if its quote price was not
+// fetched in this run. The known prices themselves are not returned.
+func (f *Fallback) GetDerivedPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	knownPrices types.Prices,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	childCtx, span := f.Tracer.Start(ctx, "Querying prices")
+	defer span.End()
+
+	prices := types.Prices{}
+	queryInfos := []types.QueryInfo{}
+
+	var lastError error
+
+	for _, provider := range f.Providers {
+		missingDenoms := []types.ChainDenom{}
+		for _, denom := range denoms {
+			if !prices.HasAll(denom.Chain, denom.DenomInfo.Denom, currencies) {
+				missingDenoms = append(missingDenoms, denom)
+			}
+		}
+
+		if len(missingDenoms) == 0 {
+			break
+		}
+
+		var providerPrices types.Prices
+		var providerQueryInfos []types.QueryInfo
+		var err error
+
+		if derivedProvider, ok := provider.(types.DerivedPriceProvider); ok {
+			derivedKnownPrices := types.Prices{}
+			for key, price := range knownPrices {
+				derivedKnownPrices[key] = price
+			}
+
+			for key, price := range prices {
+				derivedKnownPrices[key] = price
+			}
+
+			providerPrices, providerQueryInfos, err = derivedProvider.GetDerivedPrices(
+				missingDenoms,
+				currencies,
+				derivedKnownPrices,
+				childCtx,
+			)
+		} else {
+			providerPrices, providerQueryInfos, err = provider.GetPrices(missingDenoms, currencies, childCtx)
+		}
+
+		queryInfos = append(queryInfos, providerQueryInfos...)
+
+		if err != nil {
+			f.Logger.Warn().
+				Err(err).
+				Str("provider", provider.Name()).
+				Msg("Error fetching prices, trying next provider")
+			lastError = err
+			continue
+		}
+
+		for key, price := range providerPrices {
+			if _, ok := prices[key]; !ok {
+				prices[key] = price
+			}
+		}
+	}
+
+	if len(prices) == 0 && lastError != nil {
+		return nil, queryInfos, lastError
+	}
+
+	return prices, queryInfos, nil
+}
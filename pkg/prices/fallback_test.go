@@ -0,0 +1,130 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestFallbackUsesNextProvider(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos&vs_currencies=usd",
+		httpmock.NewErrorResponder(errors.New("rate limited")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/v2/cryptocurrency/quotes/latest?id=3794&convert=USD",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coinmarketcap.json")),
+	)
+
+	appConfig := &configPkg.Config{
+		PriceProviders: []string{
+			configPkg.PriceProviderCoingecko,
+			configPkg.PriceProviderCoinMarketCap,
+			configPkg.PriceProviderStatic,
+		},
+		CoinMarketCapConfig: configPkg.CoinMarketCapConfig{APIKey: "key", BaseURL: "https://example.com"},
+	}
+
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	assert.Equal(t, "cache", provider.Name())
+
+	prices, queryInfos, err := provider.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{
+			Denom:                 "uatom",
+			CoingeckoCurrency:     "cosmos",
+			CoinMarketCapCurrency: "3794",
+		}},
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{
+			Denom:        "uusdc",
+			StaticPrices: map[string]float64{"usd": 1},
+		}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 2)
+	assert.False(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+	assert.Len(t, prices, 2)
+
+	atomPrice, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 5.84, atomPrice.Value, 0.001)
+	assert.Equal(t, "coinmarketcap", atomPrice.Source)
+
+	usdcPrice, found := prices.Get("chain", "uusdc", "usd")
+	assert.True(t, found)
+	assert.Equal(t, "static", usdcPrice.Source)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestFallbackSkipsFoundPrices(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos&vs_currencies=usd",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coingecko.json")),
+	)
+
+	appConfig := &configPkg.Config{
+		PriceProviders: []string{configPkg.PriceProviderCoingecko, configPkg.PriceProviderCoinMarketCap},
+		CoinMarketCapConfig: configPkg.CoinMarketCapConfig{
+			APIKey:  "key",
+			BaseURL: "https://example.com",
+		},
+	}
+
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := provider.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{
+			Denom:                 "uatom",
+			CoingeckoCurrency:     "cosmos",
+			CoinMarketCapCurrency: "3794",
+		}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.Len(t, prices, 1)
+
+	atomPrice, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.Equal(t, "coingecko", atomPrice.Source)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestFallbackAllFailed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos&vs_currencies=usd",
+		httpmock.NewErrorResponder(errors.New("rate limited")),
+	)
+
+	appConfig := &configPkg.Config{PriceProviders: []string{configPkg.PriceProviderCoingecko}}
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := provider.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom", CoingeckoCurrency: "cosmos"}},
+	}, []string{"usd"}, context.Background())
+	require.Error(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.Nil(t, prices)
+}
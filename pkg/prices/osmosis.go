@@ -0,0 +1,153 @@
+package prices
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/http"
+	"main/pkg/types"
+	"math"
+	"net/url"
+	"strconv"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+type OsmosisSpotPriceResponse struct {
+	SpotPrice string `json:"spot_price"`
+}
+
+// Osmosis calculates a token price from an Osmosis pool spot price against
+// a quote asset, which price is already known from other price providers.
+type Osmosis struct {
+	Client *http.Client
+	Config *config.Config
+	Logger zerolog.Logger
+	Tracer trace.Tracer
+}
+
+func NewOsmosis(appConfig *config.Config, logger zerolog.Logger, tracer trace.Tracer) *Osmosis {
+	return &Osmosis{
+		Config: appConfig,
+		Client: http.NewClient(logger, config.PriceProviderOsmosis, tracer),
+		Logger: logger.With().Str("component", "osmosis").Logger(),
+		Tracer: tracer,
+	}
+}
+
+func (o *Osmosis) Name() string {
+	return config.PriceProviderOsmosis
+}
+
+func (o *Osmosis) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	return o.GetDerivedPrices(denoms, currencies, types.Prices{}, ctx)
+}
+
+func (o *Osmosis) GetDerivedPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	knownPrices types.Prices,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	childCtx, span := o.Tracer.Start(ctx, "Querying Osmosis prices")
+	defer span.End()
+
+	prices := types.Prices{}
+	queryInfos := []types.QueryInfo{}
+
+	var lastError error
+
+	for _, denom := range denoms {
+		priceConfig := denom.DenomInfo.OsmosisPrice
+		if priceConfig == nil {
+			continue
+		}
+
+		quoteChain, found := o.Config.FindChainByName(priceConfig.QuoteChain)
+		if !found {
+			o.Logger.Warn().
+				Str("chain", denom.Chain).
+				Str("denom", denom.DenomInfo.Denom).
+				Str("quote_chain", priceConfig.QuoteChain).
+				Msg("Quote chain is not found")
+			continue
+		}
+
+		quoteDenomInfo, found := quoteChain.FindDenomByName(priceConfig.QuoteDenom)
+		if !found {
+			o.Logger.Warn().
+				Str("chain", denom.Chain).
+				Str("denom", denom.DenomInfo.Denom).
+				Str("quote_denom", priceConfig.QuoteDenom).
+				Msg("Quote denom is not found")
+			continue
+		}
+
+		spotPrice, queryInfo, err := o.GetSpotPrice(denom.DenomInfo, childCtx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			o.Logger.Error().
+				Err(err).
+				Str("chain", denom.Chain).
+				Str("denom", denom.DenomInfo.Denom).
+				Msg("Could not get spot price")
+			lastError = err
+			continue
+		}
+
+		// spot price is the amount of quote asset base units for 1 base asset base unit,
+		// so to get the price of 1 display token it needs to be adjusted by exponents
+		ratio := spotPrice * math.Pow10(denom.DenomInfo.GetExponent()-quoteDenomInfo.GetExponent())
+
+		for _, currency := range currencies {
+			quotePrice, found := knownPrices.Get(priceConfig.QuoteChain, priceConfig.QuoteDenom, currency)
+			if !found {
+				continue
+			}
+
+			prices.Set(denom.Chain, denom.DenomInfo.Denom, currency, types.Price{
+				Value:  ratio * quotePrice.Value,
+				Source: o.Name(),
+			})
+		}
+	}
+
+	if len(prices) == 0 && lastError != nil {
+		return nil, queryInfos, lastError
+	}
+
+	return prices, queryInfos, nil
+}
+
+func (o *Osmosis) GetSpotPrice(denomInfo config.DenomInfo, ctx context.Context) (float64, types.QueryInfo, error) {
+	priceConfig := denomInfo.OsmosisPrice
+
+	queryURL := fmt.Sprintf(
+		"%s/osmosis/poolmanager/v1beta1/pools/%d/prices?base_asset_denom=%s&quote_asset_denom=%s",
+		priceConfig.LCDEndpoint,
+		priceConfig.PoolID,
+		url.QueryEscape(priceConfig.GetPoolBaseDenom(denomInfo.Denom)),
+		url.QueryEscape(priceConfig.GetPoolQuoteDenom()),
+	)
+
+	var response OsmosisSpotPriceResponse
+	queryInfo, _, err := o.Client.Get(queryURL, &response, types.HTTPPredicateAlwaysPass(), ctx)
+	if err != nil {
+		return 0, queryInfo, err
+	}
+
+	spotPrice, err := strconv.ParseFloat(response.SpotPrice, 64)
+	if err != nil {
+		queryInfo.Success = false
+		return 0, queryInfo, err
+	}
+
+	return spotPrice, queryInfo, nil
+}
@@ -0,0 +1,220 @@
+package prices
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getOsmosisTestConfig() *configPkg.Config {
+	return &configPkg.Config{
+		PriceProviders: []string{configPkg.PriceProviderStatic, configPkg.PriceProviderOsmosis},
+		Chains: []configPkg.Chain{
+			{
+				Name: "osmosis",
+				Denoms: []configPkg.DenomInfo{
+					{Denom: "uosmo", DenomExponent: null.IntFrom(6), StaticPrices: map[string]float64{"usd": 2}},
+				},
+			},
+			{
+				Name: "chain",
+				Denoms: []configPkg.DenomInfo{
+					{
+						Denom:         "atoken",
+						DenomExponent: null.IntFrom(18),
+						OsmosisPrice: &configPkg.OsmosisPriceConfig{
+							PoolID:        1,
+							LCDEndpoint:   "https://osmosis.example.com",
+							QuoteChain:    "osmosis",
+							QuoteDenom:    "uosmo",
+							PoolBaseDenom: "ibc/TOKEN",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestOsmosisNoKnownPrices(t *testing.T) {
+	t.Parallel()
+
+	appConfig := getOsmosisTestConfig()
+	osmosis := NewOsmosis(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	assert.Equal(t, "osmosis", osmosis.Name())
+
+	prices, queryInfos, err := osmosis.GetPrices([]types.ChainDenom{
+		{Chain: "osmosis", DenomInfo: appConfig.Chains[0].Denoms[0]},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Empty(t, queryInfos)
+}
+
+func TestOsmosisQuoteNotFound(t *testing.T) {
+	t.Parallel()
+
+	appConfig := getOsmosisTestConfig()
+	osmosis := NewOsmosis(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	denomInfo := appConfig.Chains[1].Denoms[0]
+	denomInfo.OsmosisPrice = &configPkg.OsmosisPriceConfig{
+		PoolID:      1,
+		LCDEndpoint: "https://osmosis.example.com",
+		QuoteChain:  "unknown",
+		QuoteDenom:  "uosmo",
+	}
+
+	prices, queryInfos, err := osmosis.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: denomInfo},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Empty(t, queryInfos)
+
+	denomInfo.OsmosisPrice.QuoteChain = "osmosis"
+	denomInfo.OsmosisPrice.QuoteDenom = "unknown"
+
+	prices, queryInfos, err = osmosis.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: denomInfo},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, prices)
+	assert.Empty(t, queryInfos)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestOsmosisQueryFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://osmosis.example.com/osmosis/poolmanager/v1beta1/pools/1/prices?base_asset_denom=ibc%2FTOKEN&quote_asset_denom=uosmo",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	appConfig := getOsmosisTestConfig()
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := provider.GetPrices([]types.ChainDenom{
+		{Chain: "osmosis", DenomInfo: appConfig.Chains[0].Denoms[0]},
+		{Chain: "chain", DenomInfo: appConfig.Chains[1].Denoms[0]},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+	assert.Len(t, prices, 1)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestOsmosisInvalidSpotPrice(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://osmosis.example.com/osmosis/poolmanager/v1beta1/pools/1/prices?base_asset_denom=ibc%2FTOKEN&quote_asset_denom=uosmo",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("osmosis-spot-price-invalid.json")),
+	)
+
+	appConfig := getOsmosisTestConfig()
+	osmosis := NewOsmosis(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := osmosis.GetDerivedPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: appConfig.Chains[1].Denoms[0]},
+	}, []string{"usd"}, types.Prices{}, context.Background())
+	require.Error(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+	assert.Nil(t, prices)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestOsmosisOk(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://osmosis.example.com/osmosis/poolmanager/v1beta1/pools/1/prices?base_asset_denom=ibc%2FTOKEN&quote_asset_denom=uosmo",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("osmosis-spot-price.json")),
+	)
+
+	appConfig := getOsmosisTestConfig()
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	prices, queryInfos, err := provider.GetPrices([]types.ChainDenom{
+		{Chain: "osmosis", DenomInfo: appConfig.Chains[0].Denoms[0]},
+		{Chain: "chain", DenomInfo: appConfig.Chains[1].Denoms[0]},
+	}, []string{"usd", "eur"}, context.Background())
+	require.NoError(t, err)
+	require.Len(t, queryInfos, 1)
+	assert.True(t, queryInfos[0].Success)
+	assert.Len(t, prices, 2)
+
+	// 1 atoken = 0.25 uosmo, so 1 token (10^18 atoken) = 0.25 * 10^12 osmo = $5 * 10^11
+	price, found := prices.Get("chain", "atoken", "usd")
+	assert.True(t, found)
+	assert.InEpsilon(t, 5e11, price.Value, 0.0001)
+	assert.Equal(t, "osmosis", price.Source)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestOsmosisUsesCachedQuotePrice(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	spotPriceURL := "https://osmosis.example.com/osmosis/poolmanager/v1beta1/pools/1/prices" +
+		"?base_asset_denom=ibc%2FTOKEN&quote_asset_denom=uosmo"
+
+	httpmock.RegisterResponder("GET", spotPriceURL, httpmock.NewErrorResponder(errors.New("custom error")))
+
+	appConfig := getOsmosisTestConfig()
+	appConfig.PriceCacheConfig = configPkg.PriceCacheConfig{TTL: time.Hour, MaxStaleness: time.Hour}
+	provider := NewPriceProvider(appConfig, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	denoms := []types.ChainDenom{
+		{Chain: "osmosis", DenomInfo: appConfig.Chains[0].Denoms[0]},
+		{Chain: "chain", DenomInfo: appConfig.Chains[1].Denoms[0]},
+	}
+
+	prices, _, err := provider.GetPrices(denoms, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	_, found := prices.Get("chain", "atoken", "usd")
+	assert.False(t, found)
+
+	// the quote price is cached and fresh, so once the derived one's check expires, only it
+	// is refreshed, and it's calculated from the cached quote price
+	httpmock.RegisterResponder(
+		"GET",
+		spotPriceURL,
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("osmosis-spot-price.json")),
+	)
+
+	cache, ok := provider.(*Cache)
+	require.True(t, ok)
+	cache.CheckedAt[types.PriceKey{Chain: "chain", Denom: "atoken", Currency: "usd"}] = time.Now().Add(-time.Hour)
+
+	prices, _, err = provider.GetPrices(denoms, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+
+	price, found := prices.Get("chain", "atoken", "usd")
+	assert.True(t, found)
+	assert.InEpsilon(t, 5e11, price.Value, 0.0001)
+
+	price, found = prices.Get("osmosis", "uosmo", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 2, price.Value, 0.001)
+}
@@ -0,0 +1,34 @@
+package prices
+
+import (
+	"main/pkg/config"
+	"main/pkg/types"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+func NewPriceProvider(appConfig *config.Config, logger zerolog.Logger, tracer trace.Tracer) types.PriceProvider {
+	providers := make([]types.PriceProvider, 0, len(appConfig.PriceProviders))
+
+	for _, provider := range appConfig.PriceProviders {
+		switch provider {
+		case config.PriceProviderCoingecko:
+			providers = append(providers, NewCoingecko(appConfig.CoingeckoConfig, logger, tracer))
+		case config.PriceProviderCoinMarketCap:
+			providers = append(providers, NewCoinMarketCap(appConfig.CoinMarketCapConfig, logger, tracer))
+		case config.PriceProviderStatic:
+			providers = append(providers, NewStatic())
+		case config.PriceProviderOsmosis:
+			providers = append(providers, NewOsmosis(appConfig, logger, tracer))
+		}
+	}
+
+	return NewCache(
+		NewFallback(providers, logger, tracer),
+		appConfig.PriceCacheConfig,
+		logger,
+		tracer,
+	)
+}
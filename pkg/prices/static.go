@@ -0,0 +1,38 @@
+package prices
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/types"
+)
+
+type Static struct{}
+
+func NewStatic() *Static {
+	return &Static{}
+}
+
+func (s *Static) Name() string {
+	return config.PriceProviderStatic
+}
+
+func (s *Static) GetPrices(
+	denoms []types.ChainDenom,
+	currencies []string,
+	ctx context.Context,
+) (types.Prices, []types.QueryInfo, error) {
+	prices := types.Prices{}
+
+	for _, denom := range denoms {
+		for _, currency := range currencies {
+			if price, ok := denom.DenomInfo.StaticPrices[currency]; ok {
+				prices.Set(denom.Chain, denom.DenomInfo.Denom, currency, types.Price{
+					Value:  price,
+					Source: s.Name(),
+				})
+			}
+		}
+	}
+
+	return prices, []types.QueryInfo{}, nil
+}
@@ -0,0 +1,34 @@
+package prices
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	"main/pkg/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStaticPrices(t *testing.T) {
+	t.Parallel()
+
+	static := NewStatic()
+	assert.Equal(t, "static", static.Name())
+
+	prices, queryInfos, err := static.GetPrices([]types.ChainDenom{
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{
+			Denom:        "uusdc",
+			StaticPrices: map[string]float64{"usd": 1, "eur": 0.92},
+		}},
+		{Chain: "chain", DenomInfo: configPkg.DenomInfo{Denom: "uatom"}},
+	}, []string{"usd"}, context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, queryInfos)
+	assert.Len(t, prices, 1)
+
+	price, found := prices.Get("chain", "uusdc", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 1, price.Value, 0.001)
+	assert.Equal(t, "static", price.Source)
+}
@@ -0,0 +1,253 @@
+package proto
+
+import (
+	"context"
+	"fmt"
+	"main/pkg/types"
+	"time"
+
+	"cosmossdk.io/math"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+const QueryAllBalancesMethod = "/cosmos.bank.v1beta1.Query/AllBalances"
+
+// Invoker does a query using one of the transports, checking the block height
+// the same way as for LCD queries.
+type Invoker func(
+	method string,
+	address string,
+	request Message,
+	response Message,
+	ctx context.Context,
+) (types.QueryInfo, error)
+
+// GetAllBalances fetches all pages of the wallet balances.
+func GetAllBalances(
+	invoke Invoker,
+	address string,
+	pageSize uint64,
+	ctx context.Context,
+) (*types.BalanceResponse, types.QueryInfo, error) {
+	balances := &types.BalanceResponse{}
+
+	var (
+		nextKey       []byte
+		totalDuration time.Duration
+		pages         int
+	)
+
+	for {
+		request := &QueryAllBalancesRequest{
+			Address:    address,
+			Pagination: &PageRequest{Key: nextKey, Limit: pageSize},
+		}
+		response := &QueryAllBalancesResponse{}
+
+		queryInfo, err := invoke(QueryAllBalancesMethod, address, request, response, ctx)
+
+		totalDuration += queryInfo.Duration
+		queryInfo.Duration = totalDuration
+		queryInfo.Pages = pages
+
+		if err != nil {
+			return nil, queryInfo, err
+		}
+
+		pageBalances, err := response.ToBalanceResponse()
+		if err != nil {
+			queryInfo.Success = false
+			return nil, queryInfo, err
+		}
+
+		pages++
+		queryInfo.Pages = pages
+
+		balances.Balances = append(balances.Balances, pageBalances.Balances...)
+
+		nextKey = response.Pagination.NextKey
+		if len(nextKey) == 0 {
+			return balances, queryInfo, nil
+		}
+	}
+}
+
+// QueryAllBalancesRequest is cosmos.bank.v1beta1.QueryAllBalancesRequest.
+type QueryAllBalancesRequest struct {
+	Address    string
+	Pagination *PageRequest
+}
+
+func (r *QueryAllBalancesRequest) Marshal() ([]byte, error) {
+	var data []byte
+	data = appendString(data, 1, r.Address)
+
+	if r.Pagination != nil && !r.Pagination.IsEmpty() {
+		paginationBytes, err := r.Pagination.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		data = appendBytes(data, 2, paginationBytes)
+	}
+
+	return data, nil
+}
+
+func (r *QueryAllBalancesRequest) Unmarshal(data []byte) error {
+	return iterateFields(data, func(field Field) error {
+		switch field.Number {
+		case 1:
+			r.Address = string(field.Bytes)
+		case 2:
+			r.Pagination = &PageRequest{}
+			return r.Pagination.Unmarshal(field.Bytes)
+		}
+
+		return nil
+	})
+}
+
+// QueryAllBalancesResponse is cosmos.bank.v1beta1.QueryAllBalancesResponse.
+type QueryAllBalancesResponse struct {
+	Balances   []Coin
+	Pagination PageResponse
+}
+
+func (r *QueryAllBalancesResponse) Marshal() ([]byte, error) {
+	var data []byte
+
+	for _, coin := range r.Balances {
+		coinBytes, err := coin.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		data = appendBytes(data, 1, coinBytes)
+	}
+
+	paginationBytes, err := r.Pagination.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return appendBytes(data, 2, paginationBytes), nil
+}
+
+func (r *QueryAllBalancesResponse) Unmarshal(data []byte) error {
+	return iterateFields(data, func(field Field) error {
+		switch field.Number {
+		case 1:
+			coin := Coin{}
+			if err := coin.Unmarshal(field.Bytes); err != nil {
+				return err
+			}
+
+			r.Balances = append(r.Balances, coin)
+		case 2:
+			return r.Pagination.Unmarshal(field.Bytes)
+		}
+
+		return nil
+	})
+}
+
+func (r *QueryAllBalancesResponse) ToBalanceResponse() (*types.BalanceResponse, error) {
+	balances := make(types.Balances, len(r.Balances))
+
+	for index, coin := range r.Balances {
+		amount, err := math.LegacyNewDecFromStr(coin.Amount)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing amount of %s: %s", coin.Denom, err)
+		}
+
+		balances[index] = types.Balance{Denom: coin.Denom, Amount: amount}
+	}
+
+	return &types.BalanceResponse{Balances: balances}, nil
+}
+
+// Coin is cosmos.base.v1beta1.Coin.
+type Coin struct {
+	Denom  string
+	Amount string
+}
+
+func (c *Coin) Marshal() ([]byte, error) {
+	var data []byte
+	data = appendString(data, 1, c.Denom)
+	data = appendString(data, 2, c.Amount)
+	return data, nil
+}
+
+func (c *Coin) Unmarshal(data []byte) error {
+	return iterateFields(data, func(field Field) error {
+		switch field.Number {
+		case 1:
+			c.Denom = string(field.Bytes)
+		case 2:
+			c.Amount = string(field.Bytes)
+		}
+
+		return nil
+	})
+}
+
+// PageRequest is cosmos.base.query.v1beta1.PageRequest.
+type PageRequest struct {
+	Key   []byte
+	Limit uint64
+}
+
+func (p *PageRequest) IsEmpty() bool {
+	return len(p.Key) == 0 && p.Limit == 0
+}
+
+func (p *PageRequest) Marshal() ([]byte, error) {
+	var data []byte
+
+	if len(p.Key) > 0 {
+		data = appendBytes(data, 1, p.Key)
+	}
+
+	data = appendUint64(data, 3, p.Limit)
+	return data, nil
+}
+
+func (p *PageRequest) Unmarshal(data []byte) error {
+	return iterateFields(data, func(field Field) error {
+		switch {
+		case field.Number == 1 && field.Type == protowire.BytesType:
+			p.Key = field.Bytes
+		case field.Number == 3 && field.Type == protowire.VarintType:
+			p.Limit = field.Varint
+		}
+
+		return nil
+	})
+}
+
+// PageResponse is cosmos.base.query.v1beta1.PageResponse.
+type PageResponse struct {
+	NextKey []byte
+}
+
+func (p *PageResponse) Marshal() ([]byte, error) {
+	var data []byte
+
+	if len(p.NextKey) > 0 {
+		data = appendBytes(data, 1, p.NextKey)
+	}
+
+	return data, nil
+}
+
+func (p *PageResponse) Unmarshal(data []byte) error {
+	return iterateFields(data, func(field Field) error {
+		if field.Number == 1 && field.Type == protowire.BytesType {
+			p.NextKey = field.Bytes
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,166 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestQueryAllBalancesRequestMarshal(t *testing.T) {
+	t.Parallel()
+
+	request := &QueryAllBalancesRequest{Address: "address"}
+	data, err := request.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x07, 'a', 'd', 'd', 'r', 'e', 's', 's'}, data)
+
+	decoded := &QueryAllBalancesRequest{}
+	require.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, "address", decoded.Address)
+}
+
+func TestQueryAllBalancesResponseUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	response := &QueryAllBalancesResponse{Balances: []Coin{
+		{Denom: "uatom", Amount: "123456"},
+		{Denom: "ustake", Amount: "1"},
+	}}
+	data, err := response.Marshal()
+	require.NoError(t, err)
+
+	// unknown fields of other types should be skipped
+	data = protowire.AppendTag(data, 3, protowire.VarintType)
+	data = protowire.AppendVarint(data, 100)
+
+	decoded := &QueryAllBalancesResponse{}
+	require.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, response.Balances, decoded.Balances)
+
+	balances, err := decoded.ToBalanceResponse()
+	require.NoError(t, err)
+	require.Len(t, balances.Balances, 2)
+	assert.Equal(t, "uatom", balances.Balances[0].Denom)
+	assert.Equal(t, int64(123456), balances.Balances[0].Amount.TruncateInt64())
+}
+
+func TestQueryAllBalancesResponseInvalid(t *testing.T) {
+	t.Parallel()
+
+	decoded := &QueryAllBalancesResponse{}
+	require.Error(t, decoded.Unmarshal([]byte{0x0a, 0x10, 0x01}))
+
+	response := &QueryAllBalancesResponse{Balances: []Coin{{Denom: "uatom", Amount: "invalid"}}}
+	_, err := response.ToBalanceResponse()
+	require.ErrorContains(t, err, "error parsing amount of uatom")
+}
+
+func TestCodec(t *testing.T) {
+	t.Parallel()
+
+	codec := Codec{}
+	assert.Equal(t, "proto", codec.Name())
+
+	_, err := codec.Marshal("string")
+	require.Error(t, err)
+	require.Error(t, codec.Unmarshal([]byte{}, "string"))
+
+	data, err := codec.Marshal(&QueryAllBalancesRequest{Address: "address"})
+	require.NoError(t, err)
+
+	request := &QueryAllBalancesRequest{}
+	require.NoError(t, codec.Unmarshal(data, request))
+	assert.Equal(t, "address", request.Address)
+}
+
+func TestPageRequestMarshal(t *testing.T) {
+	t.Parallel()
+
+	request := &QueryAllBalancesRequest{
+		Address:    "address",
+		Pagination: &PageRequest{Key: []byte("key"), Limit: 100},
+	}
+	data, err := request.Marshal()
+	require.NoError(t, err)
+
+	decoded := &QueryAllBalancesRequest{}
+	require.NoError(t, decoded.Unmarshal(data))
+	assert.Equal(t, request, decoded)
+
+	// empty pagination is omitted, so the request is the same as without it
+	request = &QueryAllBalancesRequest{Address: "address", Pagination: &PageRequest{}}
+	data, err = request.Marshal()
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x0a, 0x07, 'a', 'd', 'd', 'r', 'e', 's', 's'}, data)
+}
+
+func TestGetAllBalancesPagination(t *testing.T) {
+	t.Parallel()
+
+	pages := []*QueryAllBalancesResponse{
+		{
+			Balances:   []Coin{{Denom: "uatom", Amount: "1"}},
+			Pagination: PageResponse{NextKey: []byte("ustake")},
+		},
+		{Balances: []Coin{{Denom: "ustake", Amount: "2"}}},
+	}
+
+	invoke := func(
+		method string,
+		address string,
+		request Message,
+		response Message,
+		ctx context.Context,
+	) (types.QueryInfo, error) {
+		balancesRequest, ok := request.(*QueryAllBalancesRequest)
+		require.True(t, ok)
+		assert.Equal(t, QueryAllBalancesMethod, method)
+		assert.Equal(t, uint64(1), balancesRequest.Pagination.Limit)
+
+		page := pages[0]
+		if string(balancesRequest.Pagination.Key) == "ustake" {
+			page = pages[1]
+		}
+
+		data, err := page.Marshal()
+		require.NoError(t, err)
+		require.NoError(t, response.Unmarshal(data))
+
+		return types.QueryInfo{Success: true, Duration: time.Second}, nil
+	}
+
+	balances, queryInfo, err := GetAllBalances(invoke, "address", 1, context.Background())
+	require.NoError(t, err)
+	assert.True(t, queryInfo.Success)
+	assert.Equal(t, 2, queryInfo.Pages)
+	assert.Equal(t, 2*time.Second, queryInfo.Duration)
+	require.Len(t, balances.Balances, 2)
+	assert.Equal(t, "uatom", balances.Balances[0].Denom)
+	assert.Equal(t, "ustake", balances.Balances[1].Denom)
+}
+
+func TestGetAllBalancesFail(t *testing.T) {
+	t.Parallel()
+
+	invoke := func(
+		method string,
+		address string,
+		request Message,
+		response Message,
+		ctx context.Context,
+	) (types.QueryInfo, error) {
+		return types.QueryInfo{Success: false}, errors.New("custom error")
+	}
+
+	balances, queryInfo, err := GetAllBalances(invoke, "address", 1, context.Background())
+	require.Error(t, err)
+	assert.False(t, queryInfo.Success)
+	assert.Zero(t, queryInfo.Pages)
+	assert.Nil(t, balances)
+}
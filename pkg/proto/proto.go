@@ -0,0 +1,108 @@
+package proto
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Message is a protobuf message that is encoded and decoded by hand, so we won't
+// need to depend on cosmos-sdk and its generated code to query the nodes.
+type Message interface {
+	Marshal() ([]byte, error)
+	Unmarshal(data []byte) error
+}
+
+// Codec is a gRPC codec for Message.
+type Codec struct{}
+
+func (Codec) Marshal(v interface{}) ([]byte, error) {
+	message, ok := v.(Message)
+	if !ok {
+		return nil, fmt.Errorf("cannot marshal %T: not a proto message", v)
+	}
+
+	return message.Marshal()
+}
+
+func (Codec) Unmarshal(data []byte, v interface{}) error {
+	message, ok := v.(Message)
+	if !ok {
+		return fmt.Errorf("cannot unmarshal %T: not a proto message", v)
+	}
+
+	return message.Unmarshal(data)
+}
+
+func (Codec) Name() string {
+	return "proto"
+}
+
+func appendString(data []byte, number protowire.Number, value string) []byte {
+	if value == "" {
+		return data
+	}
+
+	data = protowire.AppendTag(data, number, protowire.BytesType)
+	return protowire.AppendString(data, value)
+}
+
+func appendBytes(data []byte, number protowire.Number, value []byte) []byte {
+	data = protowire.AppendTag(data, number, protowire.BytesType)
+	return protowire.AppendBytes(data, value)
+}
+
+func appendUint64(data []byte, number protowire.Number, value uint64) []byte {
+	if value == 0 {
+		return data
+	}
+
+	data = protowire.AppendTag(data, number, protowire.VarintType)
+	return protowire.AppendVarint(data, value)
+}
+
+// Field is a decoded message field. Only length-delimited and varint fields
+// are decoded, as none of the fields we need are of other types.
+type Field struct {
+	Number protowire.Number
+	Type   protowire.Type
+	Bytes  []byte
+	Varint uint64
+}
+
+func iterateFields(data []byte, callback func(field Field) error) error {
+	for len(data) > 0 {
+		number, wireType, length := protowire.ConsumeTag(data)
+		if length < 0 {
+			return protowire.ParseError(length)
+		}
+
+		data = data[length:]
+		field := Field{Number: number, Type: wireType}
+
+		switch wireType {
+		case protowire.BytesType:
+			field.Bytes, length = protowire.ConsumeBytes(data)
+		case protowire.VarintType:
+			field.Varint, length = protowire.ConsumeVarint(data)
+		default:
+			length = protowire.ConsumeFieldValue(number, wireType, data)
+		}
+
+		if length < 0 {
+			return protowire.ParseError(length)
+		}
+
+		data = data[length:]
+
+		if wireType != protowire.BytesType && wireType != protowire.VarintType {
+			continue
+		}
+
+		if err := callback(field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
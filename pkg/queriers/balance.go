@@ -2,13 +2,14 @@ package queriers
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"main/pkg/config"
-	"main/pkg/tendermint"
+	"main/pkg/state"
 	"main/pkg/types"
-	"math"
-	"sync"
+	"main/pkg/utils"
+	"time"
 
-	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -18,31 +19,26 @@ import (
 type BalanceQuerier struct {
 	Config *config.Config
 	Logger zerolog.Logger
-	RPCs   []*tendermint.RPC
+	State  *state.State
 	Tracer trace.Tracer
 }
 
 func NewBalanceQuerier(
 	config *config.Config,
+	appState *state.State,
 	logger zerolog.Logger,
 	tracer trace.Tracer,
 ) *BalanceQuerier {
-	rpcs := make([]*tendermint.RPC, len(config.Chains))
-
-	for index, chain := range config.Chains {
-		rpcs[index] = tendermint.NewRPC(chain, logger, tracer)
-	}
-
 	return &BalanceQuerier{
 		Config: config,
 		Logger: logger.With().Str("component", "balance_querier").Logger(),
-		RPCs:   rpcs,
+		State:  appState,
 		Tracer: tracer,
 	}
 }
 
 func (q *BalanceQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
-	childCtx, span := q.Tracer.Start(ctx, "Querying balance metrics")
+	_, span := q.Tracer.Start(ctx, "Querying balance metrics")
 	defer span.End()
 
 	balancesGauge := prometheus.NewGaugeVec(
@@ -50,66 +46,122 @@ func (q *BalanceQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector
 			Name: "cosmos_wallets_exporter_balance",
 			Help: "A wallet balance (in tokens)",
 		},
-		[]string{"chain", "address", "name", "group", "denom"},
+		append([]string{"token_type"}, WalletDenomLabels...),
 	)
 
-	var queryInfos []types.QueryInfo
-
-	var wg sync.WaitGroup
-	var mutex sync.Mutex
+	snapshotAgeGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_snapshot_age_seconds",
+			Help: "Time passed since the wallet balance was last fetched successfully (in seconds)",
+		},
+		[]string{"chain", "address", "name", "group"},
+	)
 
-	for index, chain := range q.Config.Chains {
-		rpc := q.RPCs[index]
+	staleGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_stale",
+			Help: "Whether the last wallet balance query failed and the previously fetched balance is served",
+		},
+		[]string{"chain", "address", "name", "group"},
+	)
 
+	for _, chain := range q.Config.Chains {
 		for _, wallet := range chain.Wallets {
-			wg.Add(1)
-			go func(wallet config.Wallet, chain config.Chain, rpc *tendermint.RPC) {
-				chainCtx, chainSpan := q.Tracer.Start(childCtx, "Querying chain and wallet")
-				chainSpan.SetAttributes(attribute.String("chain", chain.Name))
-				chainSpan.SetAttributes(attribute.String("wallet", wallet.Address))
-				defer chainSpan.End()
-
-				defer wg.Done()
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
+				continue
+			}
+
+			if !entry.UpdatedAt.IsZero() {
+				labels := prometheus.Labels{
+					"chain":   chain.Name,
+					"address": wallet.Address,
+					"name":    wallet.Name,
+					"group":   wallet.Group,
+				}
 
-				balancesResponse, queryInfo, err := rpc.GetWalletBalances(wallet.Address, chainCtx)
+				snapshotAgeGauge.With(labels).Set(time.Since(entry.UpdatedAt).Seconds())
+				staleGauge.With(labels).Set(utils.BoolToFloat64(!entry.Success))
+			}
+
+			err := errors.Join(
+				setTokenBalancesGauge(balancesGauge, q.Config, q.State, chain, wallet, entry.Balances, types.TokenTypeNative),
+				setTokenBalancesGauge(balancesGauge, q.Config, q.State, chain, wallet, entry.CW20Balances, types.TokenTypeCW20),
+				setTokenBalancesGauge(balancesGauge, q.Config, q.State, chain, wallet, entry.EVMBalances, types.TokenTypeEVM),
+				setTokenBalancesGauge(balancesGauge, q.Config, q.State, chain, wallet, entry.ERC20Balances, types.TokenTypeERC20),
+			)
+			logAmountsError(q.Logger, chain, wallet, err)
+		}
+	}
 
-				mutex.Lock()
-				defer mutex.Unlock()
+	return []prometheus.Collector{balancesGauge, snapshotAgeGauge, staleGauge}, q.State.GetQueryInfos()
+}
 
-				queryInfos = append(queryInfos, queryInfo)
+// setBalancesGauge sets the gauge for each of the wallet balances, skipping and returning
+// errors for those that cannot be converted to display units, so one broken denom
+// won't prevent the others from being exported.
+func setBalancesGauge(
+	gauge *prometheus.GaugeVec,
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	wallet config.Wallet,
+	balances types.Balances,
+) error {
+	return setTokenBalancesGauge(gauge, appConfig, appState, chain, wallet, balances, "")
+}
 
-				if err != nil {
-					q.Logger.Error().
-						Err(err).
-						Str("chain", chain.Name).
-						Str("wallet", wallet.Address).
-						Msg("Error querying balance")
-					return
-				}
+func setTokenBalancesGauge(
+	gauge *prometheus.GaugeVec,
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	wallet config.Wallet,
+	balances types.Balances,
+	tokenType string,
+) error {
+	errs := []error{}
+
+	for _, balance := range balances {
+		denom := ResolveTokenDenom(appConfig, appState, chain, balance.Denom, tokenType)
+
+		amount, err := denom.GetAmount(balance)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error converting %s amount: %s", balance.Denom, err))
+			continue
+		}
 
-				for _, balance := range balancesResponse.Balances {
-					denom := balance.Denom
-					amount := balance.Amount.MustFloat64()
-
-					denomInfo, found := chain.FindDenomByName(balance.Denom)
-					if found {
-						denom = denomInfo.GetName()
-						amount /= math.Pow10(denomInfo.DenomExponent)
-					}
-
-					balancesGauge.With(prometheus.Labels{
-						"chain":   chain.Name,
-						"address": wallet.Address,
-						"name":    wallet.Name,
-						"group":   wallet.Group,
-						"denom":   denom,
-					}).Set(amount)
-				}
-			}(wallet, chain, rpc)
+		labels := getWalletDenomLabels(chain, wallet, denom)
+		if tokenType != "" {
+			labels["token_type"] = tokenType
 		}
+
+		gauge.With(labels).Set(amount)
 	}
 
-	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func logAmountsError(logger zerolog.Logger, chain config.Chain, wallet config.Wallet, err error) {
+	if err == nil {
+		return
+	}
 
-	return []prometheus.Collector{balancesGauge}, queryInfos
+	logger.Error().
+		Err(err).
+		Str("chain", chain.Name).
+		Str("address", wallet.Address).
+		Msg("Could not convert some of the wallet amounts")
+}
+
+func getWalletDenomLabels(chain config.Chain, wallet config.Wallet, denom ResolvedDenom) prometheus.Labels {
+	return prometheus.Labels{
+		"chain":      chain.Name,
+		"address":    wallet.Address,
+		"name":       wallet.Name,
+		"group":      wallet.Group,
+		"denom":      denom.GetName(),
+		"base_denom": denom.BaseDenom,
+		"ibc_path":   denom.IBCPath,
+	}
 }
@@ -2,29 +2,23 @@ package queriers
 
 import (
 	"context"
-	"errors"
-	"main/assets"
 	configPkg "main/pkg/config"
 	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
 	"main/pkg/tracing"
+	"main/pkg/types"
 	"testing"
+	"time"
 
-	"github.com/jarcoal/httpmock"
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 )
 
-//nolint:paralleltest // disabled due to httpmock usage
-func TestBalanceQuerierFail(t *testing.T) {
-	httpmock.Activate()
-	defer httpmock.DeactivateAndReset()
-
-	httpmock.RegisterResponder(
-		"GET",
-		"https://example.com/cosmos/bank/v1beta1/balances/address",
-		httpmock.NewErrorResponder(errors.New("custom error")),
-	)
+func TestBalanceQuerierNoEntries(t *testing.T) {
+	t.Parallel()
 
 	config := &configPkg.Config{Chains: []configPkg.Chain{{
 		Name:        "chain",
@@ -32,28 +26,23 @@ func TestBalanceQuerierFail(t *testing.T) {
 		Wallets:     []configPkg.Wallet{{Address: "address"}},
 	}}}
 
+	state := statePkg.NewState()
+	state.SetChainQueryInfos("chain", []types.QueryInfo{{Chain: "chain", Success: false}})
+
 	tracer := tracing.InitNoopTracer()
-	logger := loggerPkg.GetNopLogger()
-	querier := NewBalanceQuerier(config, *logger, tracer)
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracer)
 
 	metrics, queries := querier.GetMetrics(context.Background())
 	assert.Len(t, queries, 1)
 	assert.False(t, queries[0].Success)
 
-	assert.Len(t, metrics, 1)
+	assert.Len(t, metrics, 3)
 	assert.Zero(t, testutil.CollectAndCount(metrics[0]))
+	assert.Zero(t, testutil.CollectAndCount(metrics[1]))
 }
 
-//nolint:paralleltest // disabled due to httpmock usage
 func TestBalanceQuerierOk(t *testing.T) {
-	httpmock.Activate()
-	defer httpmock.DeactivateAndReset()
-
-	httpmock.RegisterResponder(
-		"GET",
-		"https://example.com/cosmos/bank/v1beta1/balances/address",
-		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
-	)
+	t.Parallel()
 
 	config := &configPkg.Config{Chains: []configPkg.Chain{{
 		Name:        "chain",
@@ -63,34 +52,301 @@ func TestBalanceQuerierOk(t *testing.T) {
 			Name:    "name",
 			Group:   "group",
 		}},
-		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: 6}},
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
 	}}}
 
+	state := statePkg.NewState()
+	state.SetChainQueryInfos("chain", []types.QueryInfo{{Chain: "chain", Success: true}})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:   "chain",
+		Success: true,
+		Wallet:  config.Chains[0].Wallets[0],
+		Balances: types.Balances{
+			{Denom: "uatom", Amount: math.LegacyNewDec(123456)},
+			{Denom: "ustake", Amount: math.LegacyNewDec(234567)},
+		},
+		UpdatedAt: time.Now().Add(-10 * time.Second),
+	})
+
 	tracer := tracing.InitNoopTracer()
-	logger := loggerPkg.GetNopLogger()
-	querier := NewBalanceQuerier(config, *logger, tracer)
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracer)
 
 	metrics, queries := querier.GetMetrics(context.Background())
 	assert.Len(t, queries, 1)
 	assert.True(t, queries[0].Success)
-	assert.Len(t, metrics, 1)
+	assert.Len(t, metrics, 3)
 
 	balance, ok := metrics[0].(*prometheus.GaugeVec)
 	assert.True(t, ok)
 
 	assert.InDelta(t, 2, testutil.CollectAndCount(balance), 0.001)
 	assert.InDelta(t, 0.123456, testutil.ToFloat64(balance.With(prometheus.Labels{
+		"chain":      "chain",
+		"denom":      "atom",
+		"base_denom": "uatom",
+		"ibc_path":   "",
+		"token_type": "native",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+	})), 0.01)
+	assert.InDelta(t, 234567, testutil.ToFloat64(balance.With(prometheus.Labels{
+		"chain":      "chain",
+		"denom":      "ustake",
+		"base_denom": "ustake",
+		"ibc_path":   "",
+		"token_type": "native",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+	})), 0.01)
+
+	snapshotAge, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 10, testutil.ToFloat64(snapshotAge.With(prometheus.Labels{
 		"chain":   "chain",
-		"denom":   "atom",
 		"address": "address",
 		"name":    "name",
 		"group":   "group",
-	})), 0.01)
-	assert.InDelta(t, 234567, testutil.ToFloat64(balance.With(prometheus.Labels{
+	})), 1)
+
+	stale, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Zero(t, testutil.ToFloat64(stale.With(prometheus.Labels{
 		"chain":   "chain",
-		"denom":   "ustake",
 		"address": "address",
 		"name":    "name",
 		"group":   "group",
+	})))
+}
+
+func TestBalanceQuerierCW20(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets:     []configPkg.Wallet{{Address: "address", Name: "name", Group: "group"}},
+		CW20Tokens: []configPkg.CW20Token{{
+			Contract:     "contract",
+			DisplayDenom: "token",
+			Decimals:     null.IntFrom(8),
+		}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:        "chain",
+		Success:      true,
+		Wallet:       config.Chains[0].Wallets[0],
+		CW20Balances: types.Balances{{Denom: "cw20:contract", Amount: math.LegacyNewDec(150000000)}},
+		UpdatedAt:    time.Now(),
+	})
+
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, _ := querier.GetMetrics(context.Background())
+	balance, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+
+	assert.Equal(t, 1, testutil.CollectAndCount(balance))
+	assert.InDelta(t, 1.5, testutil.ToFloat64(balance.With(prometheus.Labels{
+		"chain":      "chain",
+		"denom":      "token",
+		"base_denom": "cw20:contract",
+		"ibc_path":   "",
+		"token_type": "cw20",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+	})), 0.001)
+}
+
+func TestBalanceQuerierEVM(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:           "chain",
+		LCDEndpoint:    "https://example.com",
+		EVMRPCEndpoint: "https://evm.example.com",
+		EVMNativeDenom: "aevmos",
+		Wallets:        []configPkg.Wallet{{Address: "address", Name: "name", Group: "group"}},
+		Denoms: []configPkg.DenomInfo{{
+			Denom:         "aevmos",
+			DisplayDenom:  "evmos",
+			DenomExponent: null.IntFrom(18),
+		}},
+		ERC20Tokens: []configPkg.ERC20Token{{Contract: "0xtoken", DisplayDenom: "token"}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:         "chain",
+		Success:       true,
+		Wallet:        config.Chains[0].Wallets[0],
+		EVMBalances:   types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("2000000000000000000")}},
+		ERC20Balances: types.Balances{{Denom: "erc20:0xtoken", Amount: math.LegacyMustNewDecFromStr("3000000000000000000")}},
+		UpdatedAt:     time.Now(),
+	})
+
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, _ := querier.GetMetrics(context.Background())
+	balance, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+
+	assert.Equal(t, 2, testutil.CollectAndCount(balance))
+	assert.InDelta(t, 2, testutil.ToFloat64(balance.With(prometheus.Labels{
+		"chain":      "chain",
+		"denom":      "evmos",
+		"base_denom": "aevmos",
+		"ibc_path":   "",
+		"token_type": "evm",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+	})), 0.001)
+	assert.InDelta(t, 3, testutil.ToFloat64(balance.With(prometheus.Labels{
+		"chain":      "chain",
+		"denom":      "token",
+		"base_denom": "erc20:0xtoken",
+		"ibc_path":   "",
+		"token_type": "erc20",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+	})), 0.001)
+}
+
+func TestBalanceQuerierEVMDefaultExponent(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{
+		{
+			Name:           "configured",
+			EVMNativeDenom: "aevmos",
+			Wallets:        []configPkg.Wallet{{Address: "address"}},
+			Denoms:         []configPkg.DenomInfo{{Denom: "aevmos", DisplayDenom: "evmos"}},
+		},
+		{
+			Name:           "not-configured",
+			EVMNativeDenom: "aevmos",
+			Wallets:        []configPkg.Wallet{{Address: "address"}},
+		},
+	}}
+
+	state := statePkg.NewState()
+	for _, chain := range config.Chains {
+		state.SetWalletEntry(types.WalletBalanceEntry{
+			Chain:       chain.Name,
+			Success:     true,
+			Wallet:      chain.Wallets[0],
+			EVMBalances: types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("2000000000000000000")}},
+			UpdatedAt:   time.Now(),
+		})
+	}
+
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, _ := querier.GetMetrics(context.Background())
+	balance, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+
+	// eth_getBalance returns wei, so the exponent is 18 unless it's set explicitly
+	for chain, denom := range map[string]string{"configured": "evmos", "not-configured": "aevmos"} {
+		assert.InDelta(t, 2, testutil.ToFloat64(balance.With(prometheus.Labels{
+			"chain":      chain,
+			"denom":      denom,
+			"base_denom": "aevmos",
+			"ibc_path":   "",
+			"token_type": "evm",
+			"address":    "address",
+			"name":       "",
+			"group":      "",
+		})), 0.001, chain)
+	}
+}
+
+func TestBalanceQuerierStale(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets: []configPkg.Wallet{
+			{Address: "address", Name: "name", Group: "group"},
+			{Address: "address2", Name: "name2", Group: "group"},
+		},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Success:   false,
+		Wallet:    config.Chains[0].Wallets[0],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(123456)}},
+		UpdatedAt: time.Now().Add(-10 * time.Second),
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:   "chain",
+		Success: false,
+		Wallet:  config.Chains[0].Wallets[1],
+	})
+
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, _ := querier.GetMetrics(context.Background())
+	assert.Len(t, metrics, 3)
+
+	balance, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(balance))
+
+	stale, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(stale))
+	assert.InDelta(t, 1, testutil.ToFloat64(stale.With(prometheus.Labels{
+		"chain":   "chain",
+		"address": "address",
+		"name":    "name",
+		"group":   "group",
+	})), 0.001)
+}
+
+func TestBalanceQuerierInvalidAmount(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets:     []configPkg.Wallet{{Address: "address", Name: "name", Group: "group"}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:   "chain",
+		Success: true,
+		Wallet:  config.Chains[0].Wallets[0],
+		Balances: types.Balances{
+			{Denom: "uatom"},
+			{Denom: "ustake", Amount: math.LegacyNewDec(234567)},
+		},
+		UpdatedAt: time.Now(),
+	})
+
+	querier := NewBalanceQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	// the broken balance is skipped instead of panicking, with the rest still exported
+	metrics, _ := querier.GetMetrics(context.Background())
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics[0]))
+	assert.InDelta(t, 234567, testutil.ToFloat64(metrics[0].(*prometheus.GaugeVec).With(prometheus.Labels{
+		"token_type": types.TokenTypeNative,
+		"chain":      "chain",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+		"denom":      "ustake",
+		"base_denom": "ustake",
+		"ibc_path":   "",
 	})), 0.01)
 }
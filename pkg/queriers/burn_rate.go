@@ -0,0 +1,248 @@
+package queriers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+	"sort"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+// BurnRate is a wallet denom spend rate over the balance samples window.
+type BurnRate struct {
+	// Amount is the latest wallet amount, in tokens.
+	Amount float64
+	// SpendRate is how many tokens per hour the wallet spends, only counting
+	// the balance decreases, so incoming transfers do not skew it.
+	SpendRate float64
+}
+
+// GetSecondsUntil returns the estimated time until the wallet amount goes down to the value,
+// 0 if it's already there, or false if it's never going to, as nothing is spent.
+func (r BurnRate) GetSecondsUntil(value float64) (float64, bool) {
+	if r.Amount <= value {
+		return 0, true
+	}
+
+	if r.SpendRate <= 0 {
+		return 0, false
+	}
+
+	return (r.Amount - value) / r.SpendRate * 3600, true
+}
+
+type BurnRateQuerier struct {
+	Config *config.Config
+	Logger zerolog.Logger
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewBurnRateQuerier(
+	config *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *BurnRateQuerier {
+	return &BurnRateQuerier{
+		Config: config,
+		Logger: logger.With().Str("component", "burn_rate_querier").Logger(),
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *BurnRateQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying burn rate metrics")
+	defer span.End()
+
+	spendRateGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_spend_rate",
+			Help: "Wallet spend rate (in tokens per hour) over the burn rate window, ignoring incoming transfers",
+		},
+		[]string{"chain", "address", "name", "group", "denom"},
+	)
+
+	timeToEmptyGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_time_to_empty_seconds",
+			Help: "Estimated time until the wallet balance runs out at its current spend rate, in seconds",
+		},
+		[]string{"chain", "address", "name", "group", "denom"},
+	)
+
+	timeToThresholdGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_time_to_threshold_seconds",
+			Help: "Estimated time until the wallet balance goes below its threshold at its current spend rate, in seconds",
+		},
+		[]string{"chain", "address", "name", "group", "denom", "level"},
+	)
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			samples := q.State.GetBalanceSamples(chain.Name, wallet.Address)
+
+			// at least two samples are needed to know whether anything is spent
+			if len(samples) < 2 {
+				continue
+			}
+
+			for _, denom := range getSamplesDenoms(samples) {
+				burnRate := getBurnRate(samples, denom)
+
+				labels := prometheus.Labels{
+					"chain":   chain.Name,
+					"address": wallet.Address,
+					"name":    wallet.Name,
+					"group":   wallet.Group,
+					"denom":   denom,
+				}
+
+				spendRateGauge.With(labels).Set(burnRate.SpendRate)
+
+				if seconds, ok := burnRate.GetSecondsUntil(0); ok {
+					timeToEmptyGauge.With(labels).Set(seconds)
+				}
+			}
+
+			thresholds := q.Config.GetWalletThresholds(wallet)
+			if len(thresholds) == 0 {
+				continue
+			}
+
+			entry, _ := q.State.GetWalletEntry(chain.Name, wallet.Address)
+
+			for _, threshold := range thresholds {
+				burnRate := getBurnRate(samples, q.getThresholdDenomName(chain, entry, threshold.Denom))
+
+				if seconds, ok := burnRate.GetSecondsUntil(threshold.Value); ok {
+					timeToThresholdGauge.With(prometheus.Labels{
+						"chain":   chain.Name,
+						"address": wallet.Address,
+						"name":    wallet.Name,
+						"group":   wallet.Group,
+						"denom":   threshold.Denom,
+						"level":   threshold.Level,
+					}).Set(seconds)
+				}
+			}
+		}
+	}
+
+	return []prometheus.Collector{spendRateGauge, timeToEmptyGauge, timeToThresholdGauge}, []types.QueryInfo{}
+}
+
+// NewBalanceSample converts the wallet balances to amounts in tokens per display denom,
+// summing the balances of the same denom within a token type only, the same way
+// as GetWalletDenomAmount does. The balances that fail to be converted are skipped
+// and the errors are returned along with the sample of the rest of them.
+func NewBalanceSample(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	entry types.WalletBalanceEntry,
+) (types.BalanceSample, error) {
+	sample := types.BalanceSample{
+		UpdatedAt: entry.UpdatedAt,
+		Amounts:   make(map[string]float64),
+	}
+
+	errs := []error{}
+
+	for _, tokenBalances := range getTokenTypeBalances(entry) {
+		amounts := make(map[string]float64)
+
+		for _, balance := range tokenBalances.balances {
+			denom := ResolveTokenDenom(appConfig, appState, chain, balance.Denom, tokenBalances.tokenType)
+
+			amount, err := denom.GetAmount(balance)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("error converting %s balance: %s", balance.Denom, err))
+				continue
+			}
+
+			amounts[denom.GetName()] += amount
+		}
+
+		for denom, amount := range amounts {
+			if _, ok := sample.Amounts[denom]; !ok {
+				sample.Amounts[denom] = amount
+			}
+		}
+	}
+
+	return sample, errors.Join(errs...)
+}
+
+// getSamplesDenoms returns the display denoms of all the samples amounts, so the spend rate
+// is also known for a denom that was spent completely within the window.
+func getSamplesDenoms(samples []types.BalanceSample) []string {
+	denomsMap := make(map[string]bool)
+
+	for _, sample := range samples {
+		for denom := range sample.Amounts {
+			denomsMap[denom] = true
+		}
+	}
+
+	denoms := make([]string, 0, len(denomsMap))
+	for denom := range denomsMap {
+		denoms = append(denoms, denom)
+	}
+
+	sort.Strings(denoms)
+	return denoms
+}
+
+// getThresholdDenomName returns the display denom of the threshold denom, as thresholds can
+// also be set by base denom, matching it against the latest wallet balances like threshold checks do.
+func (q *BurnRateQuerier) getThresholdDenomName(
+	chain config.Chain,
+	entry types.WalletBalanceEntry,
+	denomName string,
+) string {
+	for _, tokenBalances := range getTokenTypeBalances(entry) {
+		for _, balance := range tokenBalances.balances {
+			denom := ResolveTokenDenom(q.Config, q.State, chain, balance.Denom, tokenBalances.tokenType)
+			if isSameDenom(denom, denomName) {
+				return denom.GetName()
+			}
+		}
+	}
+
+	return ResolveDenom(q.Config, q.State, chain, denomName).GetName()
+}
+
+func getBurnRate(samples []types.BalanceSample, denom string) BurnRate {
+	spent := 0.0
+	previousAmount := 0.0
+
+	for index, sample := range samples {
+		// a missing amount means the wallet has no such balance, as zero balances are not returned
+		amount := sample.Amounts[denom]
+
+		if index > 0 && amount < previousAmount {
+			spent += previousAmount - amount
+		}
+
+		previousAmount = amount
+	}
+
+	burnRate := BurnRate{Amount: previousAmount}
+
+	hours := samples[len(samples)-1].UpdatedAt.Sub(samples[0].UpdatedAt).Hours()
+	if hours > 0 {
+		burnRate.SpendRate = spent / hours
+	}
+
+	return burnRate
+}
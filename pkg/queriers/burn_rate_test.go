@@ -0,0 +1,288 @@
+package queriers
+
+import (
+	"context"
+	"fmt"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBurnRateGetSecondsUntil(t *testing.T) {
+	t.Parallel()
+
+	seconds, ok := BurnRate{Amount: 10, SpendRate: 2}.GetSecondsUntil(4)
+	assert.True(t, ok)
+	assert.InDelta(t, 3*3600, seconds, 0.01)
+
+	seconds, ok = BurnRate{Amount: 3, SpendRate: 2}.GetSecondsUntil(4)
+	assert.True(t, ok)
+	assert.Zero(t, seconds)
+
+	_, ok = BurnRate{Amount: 10}.GetSecondsUntil(4)
+	assert.False(t, ok)
+}
+
+func TestBurnRateQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{
+		BurnRateConfig: configPkg.BurnRateConfig{Enabled: true, Window: 24 * time.Hour},
+		Chains: []configPkg.Chain{{
+			Name: "chain",
+			Wallets: []configPkg.Wallet{
+				{
+					Address: "relayer",
+					Name:    "relayer",
+					Group:   "relayers",
+					BalanceThresholds: configPkg.BalanceThresholds{
+						MinBalance:  map[string]float64{"atom": 1},
+						WarnBalance: map[string]float64{"atom": 5},
+					},
+				},
+				{Address: "drained", Name: "drained"},
+				{Address: "single", Name: "single"},
+			},
+			Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+		}},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := statePkg.NewState()
+
+	addSample := func(wallet configPkg.Wallet, offset time.Duration, balances types.Balances) {
+		entry := types.WalletBalanceEntry{
+			Chain:     "chain",
+			Wallet:    wallet,
+			Balances:  balances,
+			UpdatedAt: start.Add(offset),
+		}
+
+		sample, err := NewBalanceSample(config, state, config.Chains[0], entry)
+		require.NoError(t, err)
+
+		state.SetWalletEntry(entry)
+		state.AddBalanceSample("chain", wallet.Address, sample, config.BurnRateConfig.Window)
+	}
+
+	atom := func(amount int64) types.Balances {
+		return types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(amount * 1000000)}}
+	}
+
+	// 2 atom spent in the first hour, then a top-up, then 2 more spent, so the top-up is ignored
+	relayer := config.Chains[0].Wallets[0]
+	addSample(relayer, 0, atom(10))
+	addSample(relayer, time.Hour, atom(8))
+	addSample(relayer, 90*time.Minute, atom(20))
+	addSample(relayer, 2*time.Hour, atom(18))
+
+	// atom is not spent at all, while ustake is spent completely
+	drained := config.Chains[0].Wallets[1]
+	addSample(drained, 0, append(atom(10), types.Balance{Denom: "ustake", Amount: math.LegacyNewDec(5)}))
+	addSample(drained, time.Hour, atom(10))
+
+	addSample(config.Chains[0].Wallets[2], 0, atom(10))
+
+	querier := NewBurnRateQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 3)
+
+	// relayer atom, drained atom and ustake, nothing for a single sample
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics[0]))
+	// drained atom is not spent, so it's never going to run out
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics[1]))
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics[2]))
+
+	getLabels := func(address string, group string, denom string) prometheus.Labels {
+		return prometheus.Labels{
+			"chain":   "chain",
+			"address": address,
+			"name":    address,
+			"group":   group,
+			"denom":   denom,
+		}
+	}
+
+	spendRateGauge := metrics[0].(*prometheus.GaugeVec)
+	timeToEmptyGauge := metrics[1].(*prometheus.GaugeVec)
+	timeToThresholdGauge := metrics[2].(*prometheus.GaugeVec)
+
+	assert.InDelta(t, 2, testutil.ToFloat64(spendRateGauge.With(getLabels("relayer", "relayers", "atom"))), 0.01)
+	assert.InDelta(t, 9*3600, testutil.ToFloat64(timeToEmptyGauge.With(getLabels("relayer", "relayers", "atom"))), 0.01)
+
+	minLabels := getLabels("relayer", "relayers", "atom")
+	minLabels["level"] = configPkg.ThresholdLevelMin
+	assert.InDelta(t, 8.5*3600, testutil.ToFloat64(timeToThresholdGauge.With(minLabels)), 0.01)
+
+	warnLabels := getLabels("relayer", "relayers", "atom")
+	warnLabels["level"] = configPkg.ThresholdLevelWarn
+	assert.InDelta(t, 6.5*3600, testutil.ToFloat64(timeToThresholdGauge.With(warnLabels)), 0.01)
+
+	assert.InDelta(t, 0, testutil.ToFloat64(spendRateGauge.With(getLabels("drained", "", "atom"))), 0.01)
+	assert.InDelta(t, 5, testutil.ToFloat64(spendRateGauge.With(getLabels("drained", "", "ustake"))), 0.01)
+	assert.InDelta(t, 0, testutil.ToFloat64(timeToEmptyGauge.With(getLabels("drained", "", "ustake"))), 0.01)
+}
+
+func TestNewBalanceSample(t *testing.T) {
+	t.Parallel()
+
+	chain := configPkg.Chain{
+		Name:           "chain",
+		Denoms:         []configPkg.DenomInfo{{Denom: "aevmos", DisplayDenom: "evmos", DenomExponent: null.IntFrom(18)}},
+		EVMNativeDenom: "aevmos",
+	}
+	config := &configPkg.Config{Chains: []configPkg.Chain{chain}}
+	updatedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sample, err := NewBalanceSample(config, statePkg.NewState(), chain, types.WalletBalanceEntry{
+		Balances: types.Balances{
+			{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("2000000000000000000")},
+			{Denom: "uatom"},
+			{Denom: "ustake", Amount: math.LegacyNewDec(3)},
+		},
+		// the same funds as the bank balance, so it's not added to it
+		EVMBalances: types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("2000000000000000000")}},
+		UpdatedAt:   updatedAt,
+	})
+
+	// the invalid balance is skipped, with the rest of them converted
+	require.ErrorContains(t, err, "error converting uatom balance")
+	assert.Equal(t, updatedAt, sample.UpdatedAt)
+	assert.Equal(t, map[string]float64{"evmos": 2, "ustake": 3}, sample.Amounts)
+}
+
+func TestBurnRateQuerierThresholdByBaseDenom(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{{
+			Address:           "address",
+			BalanceThresholds: configPkg.BalanceThresholds{MinBalance: map[string]float64{"uatom": 4}},
+		}},
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := statePkg.NewState()
+
+	for index, amount := range []int64{10, 8} {
+		entry := types.WalletBalanceEntry{
+			Chain:     "chain",
+			Wallet:    config.Chains[0].Wallets[0],
+			Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(amount * 1000000)}},
+			UpdatedAt: start.Add(time.Duration(index) * time.Hour),
+		}
+
+		sample, err := NewBalanceSample(config, state, config.Chains[0], entry)
+		require.NoError(t, err)
+
+		state.SetWalletEntry(entry)
+		state.AddBalanceSample("chain", "address", sample, 24*time.Hour)
+	}
+
+	querier := NewBurnRateQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, _ := querier.GetMetrics(context.Background())
+	timeToThresholdGauge := metrics[2].(*prometheus.GaugeVec)
+
+	assert.InDelta(t, 2*3600, testutil.ToFloat64(timeToThresholdGauge.With(prometheus.Labels{
+		"chain":   "chain",
+		"address": "address",
+		"name":    "",
+		"group":   "",
+		"denom":   "uatom",
+		"level":   configPkg.ThresholdLevelMin,
+	})), 0.01)
+}
+
+func TestBurnRateQuerierLargeWindow(t *testing.T) {
+	t.Parallel()
+
+	// 5 wallets with 30 denoms each, sampled every 30s within a 24h window
+	const (
+		walletsCount = 5
+		denomsCount  = 30
+		samplesCount = 2880
+	)
+
+	chain := configPkg.Chain{Name: "chain"}
+	for index := 0; index < denomsCount; index++ {
+		chain.Denoms = append(chain.Denoms, configPkg.DenomInfo{
+			Denom:         fmt.Sprintf("udenom%d", index),
+			DisplayDenom:  fmt.Sprintf("denom%d", index),
+			DenomExponent: null.IntFrom(6),
+		})
+	}
+
+	for index := 0; index < walletsCount; index++ {
+		chain.Wallets = append(chain.Wallets, configPkg.Wallet{
+			Address:           fmt.Sprintf("address%d", index),
+			BalanceThresholds: configPkg.BalanceThresholds{MinBalance: map[string]float64{"denom0": 1}},
+		})
+	}
+
+	config := &configPkg.Config{
+		BurnRateConfig: configPkg.BurnRateConfig{Enabled: true, Window: 24 * time.Hour},
+		Chains:         []configPkg.Chain{chain},
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	state := statePkg.NewState()
+
+	for _, wallet := range chain.Wallets {
+		for sampleIndex := 0; sampleIndex < samplesCount; sampleIndex++ {
+			entry := types.WalletBalanceEntry{
+				Chain:     "chain",
+				Wallet:    wallet,
+				UpdatedAt: start.Add(time.Duration(sampleIndex) * 30 * time.Second),
+			}
+
+			for _, denom := range chain.Denoms {
+				entry.Balances = append(entry.Balances, types.Balance{
+					Denom:  denom.Denom,
+					Amount: math.LegacyNewDec(int64(samplesCount-sampleIndex) * 1000000),
+				})
+			}
+
+			sample, err := NewBalanceSample(config, state, chain, entry)
+			require.NoError(t, err)
+
+			state.SetWalletEntry(entry)
+			state.AddBalanceSample("chain", wallet.Address, sample, config.BurnRateConfig.Window)
+		}
+	}
+
+	querier := NewBurnRateQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	// a scrape only goes through the already converted amounts, so it's way below
+	// the Prometheus default scrape timeout of 10s
+	scrapeStart := time.Now()
+	metrics, _ := querier.GetMetrics(context.Background())
+	assert.Less(t, time.Since(scrapeStart), 2*time.Second)
+
+	assert.Equal(t, walletsCount*denomsCount, testutil.CollectAndCount(metrics[0]))
+	assert.Equal(t, walletsCount, testutil.CollectAndCount(metrics[2]))
+
+	// 1 token spent every 30s
+	assert.InDelta(t, 120, testutil.ToFloat64(metrics[0].(*prometheus.GaugeVec).With(prometheus.Labels{
+		"chain":   "chain",
+		"address": "address0",
+		"name":    "",
+		"group":   "",
+		"denom":   "denom0",
+	})), 0.01)
+}
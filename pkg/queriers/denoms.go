@@ -0,0 +1,217 @@
+package queriers
+
+import (
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+)
+
+// WalletDenomLabels are the labels for metrics with wallet amounts per denom.
+var WalletDenomLabels = []string{"chain", "address", "name", "group", "denom", "base_denom", "ibc_path"}
+
+// ResolvedDenom is a denom returned by the node, with its IBC denom trace,
+// if it is an IBC denom and it was resolved, and the denom info from the config,
+// with the display denom and exponent taken from the chain denoms metadata if
+// they are not set explicitly.
+type ResolvedDenom struct {
+	Denom     string
+	BaseDenom string
+	IBCPath   string
+	DenomInfo *config.DenomInfo
+	// PriceChain is the chain the denom info was found at, which is where
+	// its price is fetched for, as IBC denoms can be matched by a base denom
+	// configured on another chain.
+	PriceChain string
+}
+
+// ResolveDenom matches the denom against the config denoms and the chain denoms metadata,
+// first by the denom itself, then, if it is a resolved IBC denom, by its base denom,
+// on this chain or on any other.
+func ResolveDenom(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	denom string,
+) ResolvedDenom {
+	resolved := resolveConfigDenom(appConfig, appState, chain, denom)
+
+	if metadata, found := findDenomMetadata(appConfig, appState, chain, resolved); found {
+		resolved.DenomInfo = applyDenomMetadata(resolved.DenomInfo, metadata)
+	}
+
+	return resolved
+}
+
+// ResolveTokenDenom resolves the denom of a balance of the token type, the same way as
+// ResolveDenom, except for the native EVM balance denom, which defaults to the
+// config.DefaultEVMNativeExponent if its exponent is unknown.
+func ResolveTokenDenom(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	denom string,
+	tokenType string,
+) ResolvedDenom {
+	resolved := ResolveDenom(appConfig, appState, chain, denom)
+	if tokenType != types.TokenTypeEVM {
+		return resolved
+	}
+
+	denomInfo := config.DenomInfo{Denom: denom}
+	if resolved.DenomInfo != nil {
+		if resolved.DenomInfo.DenomExponent.Valid {
+			return resolved
+		}
+
+		denomInfo = *resolved.DenomInfo
+	}
+
+	denomInfo.DenomExponent = null.IntFrom(config.DefaultEVMNativeExponent)
+	resolved.DenomInfo = &denomInfo
+	return resolved
+}
+
+func resolveConfigDenom(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	denom string,
+) ResolvedDenom {
+	resolved := ResolvedDenom{
+		Denom:      denom,
+		BaseDenom:  denom,
+		PriceChain: chain.Name,
+	}
+
+	if denomInfo, found := chain.FindDenomByName(denom); found {
+		resolved.DenomInfo = denomInfo
+	}
+
+	if !types.IsIBCDenom(denom) {
+		return resolved
+	}
+
+	trace, found := appState.GetDenomTrace(chain.Name, denom)
+	if !found {
+		return resolved
+	}
+
+	resolved.BaseDenom = trace.BaseDenom
+	resolved.IBCPath = trace.Path
+
+	if resolved.DenomInfo != nil {
+		return resolved
+	}
+
+	if denomInfo, found := chain.FindDenomByName(trace.BaseDenom); found {
+		resolved.DenomInfo = denomInfo
+		return resolved
+	}
+
+	for _, otherChain := range appConfig.Chains {
+		if denomInfo, found := otherChain.FindDenomByName(trace.BaseDenom); found {
+			resolved.DenomInfo = denomInfo
+			resolved.PriceChain = otherChain.Name
+			return resolved
+		}
+	}
+
+	return resolved
+}
+
+func findDenomMetadata(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	resolved ResolvedDenom,
+) (types.DenomMetadata, bool) {
+	if metadata, found := appState.GetDenomMetadata(chain.Name, resolved.Denom); found {
+		return metadata, true
+	}
+
+	if resolved.BaseDenom == resolved.Denom {
+		return types.DenomMetadata{}, false
+	}
+
+	if metadata, found := appState.GetDenomMetadata(resolved.PriceChain, resolved.BaseDenom); found {
+		return metadata, true
+	}
+
+	for _, otherChain := range appConfig.Chains {
+		if metadata, found := appState.GetDenomMetadata(otherChain.Name, resolved.BaseDenom); found {
+			return metadata, true
+		}
+	}
+
+	return types.DenomMetadata{}, false
+}
+
+// applyDenomMetadata returns a copy of the config denom info with the display denom and exponent
+// filled from the metadata, if they are not set in the config, or a new one if there's no config.
+func applyDenomMetadata(denomInfo *config.DenomInfo, metadata types.DenomMetadata) *config.DenomInfo {
+	applied := config.DenomInfo{Denom: metadata.Base}
+	if denomInfo != nil {
+		applied = *denomInfo
+	}
+
+	if applied.DisplayDenom == "" {
+		applied.DisplayDenom = metadata.Display
+	}
+
+	if !applied.DenomExponent.Valid {
+		exponent, found := metadata.GetDisplayExponent()
+
+		// without the display unit the exponent is unknown, so the amount
+		// is exported in base units unless the denom is in the config
+		if found || denomInfo == nil {
+			applied.DenomExponent = null.IntFrom(int64(exponent))
+		}
+	}
+
+	return &applied
+}
+
+func (d ResolvedDenom) GetName() string {
+	if d.DenomInfo != nil {
+		return d.DenomInfo.GetName()
+	}
+
+	return d.BaseDenom
+}
+
+func (d ResolvedDenom) GetExponent() int {
+	if d.DenomInfo != nil {
+		return d.DenomInfo.GetExponent()
+	}
+
+	return 0
+}
+
+// GetDisplayAmount returns the balance amount in display units, computed exactly
+// (up to the 18 decimal places decimals have), unlike with float64 math.
+func (d ResolvedDenom) GetDisplayAmount(balance types.Balance) (math.LegacyDec, error) {
+	return utils.ToDisplayAmount(balance.Amount, d.GetExponent())
+}
+
+// GetAmount returns the balance amount in display units as a float64, for metrics.
+func (d ResolvedDenom) GetAmount(balance types.Balance) (float64, error) {
+	amount, err := d.GetDisplayAmount(balance)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount.Float64()
+}
+
+// GetPrice returns the denom price fetched for the config denom it was matched to.
+func (d ResolvedDenom) GetPrice(prices types.Prices, currency string) (types.Price, bool) {
+	if d.DenomInfo == nil {
+		return types.Price{}, false
+	}
+
+	return prices.Get(d.PriceChain, d.DenomInfo.Denom, currency)
+}
@@ -0,0 +1,244 @@
+package queriers
+
+import (
+	configPkg "main/pkg/config"
+	statePkg "main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIBCDenom = "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2"
+
+func TestResolveDenomNative(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:   "chain",
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	state := statePkg.NewState()
+
+	denom := ResolveDenom(config, state, config.Chains[0], "uatom")
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "atom", denom.GetName())
+	assert.Equal(t, "uatom", denom.BaseDenom)
+	assert.Empty(t, denom.IBCPath)
+	assert.Equal(t, "chain", denom.PriceChain)
+	assert.Equal(t, "1.5", getDisplayAmount(t, denom, math.LegacyNewDec(1500000)))
+
+	unknown := ResolveDenom(config, state, config.Chains[0], "ustake")
+	assert.Nil(t, unknown.DenomInfo)
+	assert.Equal(t, "ustake", unknown.GetName())
+	assert.Equal(t, "1500000", getDisplayAmount(t, unknown, math.LegacyNewDec(1500000)))
+
+	_, found := unknown.GetPrice(types.Prices{}, "usd")
+	assert.False(t, found)
+}
+
+func TestResolveDenomIBCNotResolved(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{Name: "chain"}}}
+	state := statePkg.NewState()
+
+	denom := ResolveDenom(config, state, config.Chains[0], testIBCDenom)
+	assert.Nil(t, denom.DenomInfo)
+	assert.Equal(t, testIBCDenom, denom.GetName())
+	assert.Equal(t, testIBCDenom, denom.BaseDenom)
+	assert.Empty(t, denom.IBCPath)
+}
+
+func TestResolveDenomIBCSameChain(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:   "chain",
+		Denoms: []configPkg.DenomInfo{{Denom: "uosmo", DisplayDenom: "osmo", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetDenomTrace("chain", testIBCDenom, types.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"})
+
+	denom := ResolveDenom(config, state, config.Chains[0], testIBCDenom)
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "osmo", denom.GetName())
+	assert.Equal(t, "uosmo", denom.BaseDenom)
+	assert.Equal(t, "transfer/channel-141", denom.IBCPath)
+	assert.Equal(t, "chain", denom.PriceChain)
+}
+
+func TestResolveDenomIBCExplicitConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Denoms: []configPkg.DenomInfo{
+			{Denom: "uosmo", DisplayDenom: "osmo", DenomExponent: null.IntFrom(6)},
+			{Denom: testIBCDenom, DisplayDenom: "ibc-osmo", DenomExponent: null.IntFrom(6)},
+		},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetDenomTrace("chain", testIBCDenom, types.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"})
+
+	denom := ResolveDenom(config, state, config.Chains[0], testIBCDenom)
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "ibc-osmo", denom.GetName())
+	assert.Equal(t, "uosmo", denom.BaseDenom)
+	assert.Equal(t, "transfer/channel-141", denom.IBCPath)
+}
+
+func TestResolveDenomIBCOtherChain(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{
+		{Name: "cosmos"},
+		{
+			Name:   "osmosis",
+			Denoms: []configPkg.DenomInfo{{Denom: "uosmo", DisplayDenom: "osmo", DenomExponent: null.IntFrom(6)}},
+		},
+	}}
+
+	state := statePkg.NewState()
+	state.SetDenomTrace("cosmos", testIBCDenom, types.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"})
+
+	denom := ResolveDenom(config, state, config.Chains[0], testIBCDenom)
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "osmo", denom.GetName())
+	assert.Equal(t, "osmosis", denom.PriceChain)
+
+	prices := types.Prices{}
+	prices.Set("osmosis", "uosmo", "usd", types.Price{Value: 0.5})
+
+	price, found := denom.GetPrice(prices, "usd")
+	require.True(t, found)
+	assert.InDelta(t, 0.5, price.Value, 0.001)
+}
+
+func TestResolveDenomMetadata(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:   "chain",
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", CoingeckoCurrency: "cosmos"}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetChainDenomsMetadata("chain", []types.DenomMetadata{
+		{
+			Base:       "aevmos",
+			Display:    "evmos",
+			DenomUnits: []types.DenomUnit{{Denom: "aevmos", Exponent: 0}, {Denom: "evmos", Exponent: 18}},
+		},
+		{
+			Base:       "uatom",
+			Display:    "atom",
+			DenomUnits: []types.DenomUnit{{Denom: "uatom", Exponent: 0}, {Denom: "atom", Exponent: 6}},
+		},
+		{
+			Base:    "unknown",
+			Display: "unknown-display",
+		},
+	})
+
+	evmos := ResolveDenom(config, state, config.Chains[0], "aevmos")
+	require.NotNil(t, evmos.DenomInfo)
+	assert.Equal(t, "evmos", evmos.GetName())
+	assert.Equal(t, "1.5", getDisplayAmount(t, evmos, math.LegacyMustNewDecFromStr("1500000000000000000")))
+
+	// config denom without display denom and exponent is completed from the metadata
+	atom := ResolveDenom(config, state, config.Chains[0], "uatom")
+	require.NotNil(t, atom.DenomInfo)
+	assert.Equal(t, "atom", atom.GetName())
+	assert.Equal(t, "cosmos", atom.DenomInfo.CoingeckoCurrency)
+	assert.Equal(t, 6, atom.DenomInfo.GetExponent())
+	assert.Empty(t, config.Chains[0].Denoms[0].DisplayDenom)
+
+	// without the display unit the amount is kept in base units
+	unknown := ResolveDenom(config, state, config.Chains[0], "unknown")
+	require.NotNil(t, unknown.DenomInfo)
+	assert.Equal(t, "unknown-display", unknown.GetName())
+	assert.Equal(t, "100", getDisplayAmount(t, unknown, math.LegacyNewDec(100)))
+}
+
+func TestResolveDenomMetadataOverriddenByConfig(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Denoms: []configPkg.DenomInfo{{
+			Denom:         "aevmos",
+			DisplayDenom:  "EVMOS",
+			DenomExponent: null.IntFrom(9),
+		}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetChainDenomsMetadata("chain", []types.DenomMetadata{{
+		Base:       "aevmos",
+		Display:    "evmos",
+		DenomUnits: []types.DenomUnit{{Denom: "aevmos", Exponent: 0}, {Denom: "evmos", Exponent: 18}},
+	}})
+
+	denom := ResolveDenom(config, state, config.Chains[0], "aevmos")
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "EVMOS", denom.GetName())
+	assert.Equal(t, 9, denom.DenomInfo.GetExponent())
+}
+
+func TestResolveDenomIBCMetadataOtherChain(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{Name: "cosmos"}, {Name: "osmosis"}}}
+
+	state := statePkg.NewState()
+	state.SetDenomTrace("cosmos", testIBCDenom, types.DenomTrace{Path: "transfer/channel-141", BaseDenom: "uosmo"})
+	state.SetChainDenomsMetadata("osmosis", []types.DenomMetadata{{
+		Base:       "uosmo",
+		Display:    "osmo",
+		DenomUnits: []types.DenomUnit{{Denom: "uosmo", Exponent: 0}, {Denom: "osmo", Exponent: 6}},
+	}})
+
+	denom := ResolveDenom(config, state, config.Chains[0], testIBCDenom)
+	require.NotNil(t, denom.DenomInfo)
+	assert.Equal(t, "osmo", denom.GetName())
+	assert.Equal(t, "uosmo", denom.BaseDenom)
+	assert.Equal(t, "1", getDisplayAmount(t, denom, math.LegacyNewDec(1000000)))
+}
+
+func getDisplayAmount(t *testing.T, denom ResolvedDenom, amount math.LegacyDec) string {
+	t.Helper()
+
+	displayAmount, err := denom.GetDisplayAmount(types.Balance{Amount: amount})
+	require.NoError(t, err)
+
+	return utils.FormatAmount(displayAmount)
+}
+
+func TestResolvedDenomGetAmountExact(t *testing.T) {
+	t.Parallel()
+
+	denom := ResolvedDenom{
+		Denom:     "aevmos",
+		BaseDenom: "aevmos",
+		DenomInfo: &configPkg.DenomInfo{Denom: "aevmos", DenomExponent: null.IntFrom(18)},
+	}
+
+	// more significant digits than float64 can represent
+	amount := math.LegacyMustNewDecFromStr("123456789123456789123456789")
+	assert.Equal(t, "123456789.123456789123456789", getDisplayAmount(t, denom, amount))
+
+	floatAmount, err := denom.GetAmount(types.Balance{Amount: amount})
+	require.NoError(t, err)
+	assert.InDelta(t, 123456789.12345679, floatAmount, 0.0000001)
+
+	_, err = denom.GetAmount(types.Balance{Denom: "aevmos"})
+	require.ErrorContains(t, err, "amount is nil")
+}
@@ -0,0 +1,133 @@
+package queriers
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type EndpointsQuerier struct {
+	Config *config.Config
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewEndpointsQuerier(
+	config *config.Config,
+	appState *state.State,
+	tracer trace.Tracer,
+) *EndpointsQuerier {
+	return &EndpointsQuerier{
+		Config: config,
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *EndpointsQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying endpoints metrics")
+	defer span.End()
+
+	successGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_lcd_endpoint_success",
+			Help: "A count of successful queries to LCD endpoint since the app start",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	errorGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_lcd_endpoint_error",
+			Help: "A count of failed queries to LCD endpoint since the app start",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	latencyGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_lcd_endpoint_latency_seconds",
+			Help: "Time it took LCD endpoint to respond to the last query (in seconds)",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	heightGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_lcd_endpoint_height",
+			Help: "The last block height returned by LCD endpoint",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	healthyGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_lcd_endpoint_healthy",
+			Help: "Whether LCD endpoint is healthy (not failing and not lagging behind others)",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	heightRegressionsGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_height_regressions",
+			Help: "A count of responses from LCD endpoint with an older block than the one returned previously",
+		},
+		[]string{"chain", "endpoint"},
+	)
+
+	walletEndpointGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_wallet_endpoint",
+			Help: "LCD endpoint that served the last successful wallet balance query",
+		},
+		[]string{"chain", "address", "name", "group", "endpoint"},
+	)
+
+	for _, chain := range q.Config.Chains {
+		for _, endpoint := range q.State.GetChainEndpoints(chain.Name) {
+			labels := prometheus.Labels{
+				"chain":    chain.Name,
+				"endpoint": endpoint.URL,
+			}
+
+			successGauge.With(labels).Set(float64(endpoint.Successes))
+			errorGauge.With(labels).Set(float64(endpoint.Failures))
+			latencyGauge.With(labels).Set(endpoint.Latency.Seconds())
+			heightGauge.With(labels).Set(float64(endpoint.Height))
+			healthyGauge.With(labels).Set(utils.BoolToFloat64(endpoint.Healthy))
+			heightRegressionsGauge.With(labels).Set(float64(endpoint.HeightRegressions))
+		}
+
+		for _, wallet := range chain.Wallets {
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found || entry.Endpoint == "" {
+				continue
+			}
+
+			walletEndpointGauge.With(prometheus.Labels{
+				"chain":    chain.Name,
+				"address":  wallet.Address,
+				"name":     wallet.Name,
+				"group":    wallet.Group,
+				"endpoint": entry.Endpoint,
+			}).Set(1)
+		}
+	}
+
+	return []prometheus.Collector{
+		successGauge,
+		errorGauge,
+		latencyGauge,
+		heightGauge,
+		healthyGauge,
+		heightRegressionsGauge,
+		walletEndpointGauge,
+	}, []types.QueryInfo{}
+}
@@ -0,0 +1,94 @@
+package queriers
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEndpointsQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{
+			{Address: "address", Name: "name", Group: "group"},
+			{Address: "address2", Name: "name2", Group: "group"},
+		},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetChainEndpoints("chain", []types.EndpointStatus{
+		{
+			URL:               "https://first.example.com",
+			Successes:         10,
+			Failures:          2,
+			Latency:           500 * time.Millisecond,
+			Height:            100,
+			Healthy:           true,
+			HeightRegressions: 5,
+		},
+		{URL: "https://second.example.com", Failures: 3},
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:    "chain",
+		Wallet:   config.Chains[0].Wallets[0],
+		Endpoint: "https://first.example.com",
+	})
+
+	querier := NewEndpointsQuerier(config, state, tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 7)
+
+	labels := prometheus.Labels{"chain": "chain", "endpoint": "https://first.example.com"}
+
+	success, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 2, testutil.CollectAndCount(success))
+	assert.InDelta(t, 10, testutil.ToFloat64(success.With(labels)), 0.001)
+
+	errors, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 2, testutil.ToFloat64(errors.With(labels)), 0.001)
+
+	latency, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 0.5, testutil.ToFloat64(latency.With(labels)), 0.001)
+
+	height, ok := metrics[3].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 100, testutil.ToFloat64(height.With(labels)), 0.001)
+
+	healthy, ok := metrics[4].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 1, testutil.ToFloat64(healthy.With(labels)), 0.001)
+	assert.InDelta(t, 0, testutil.ToFloat64(healthy.With(prometheus.Labels{
+		"chain":    "chain",
+		"endpoint": "https://second.example.com",
+	})), 0.001)
+
+	heightRegressions, ok := metrics[5].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 5, testutil.ToFloat64(heightRegressions.With(labels)), 0.001)
+
+	walletEndpoint, ok := metrics[6].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(walletEndpoint))
+	assert.InDelta(t, 1, testutil.ToFloat64(walletEndpoint.With(prometheus.Labels{
+		"chain":    "chain",
+		"address":  "address",
+		"name":     "name",
+		"group":    "group",
+		"endpoint": "https://first.example.com",
+	})), 0.001)
+}
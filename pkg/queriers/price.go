@@ -2,9 +2,10 @@ package queriers
 
 import (
 	"context"
-	coingeckoPkg "main/pkg/coingecko"
 	"main/pkg/config"
+	"main/pkg/state"
 	"main/pkg/types"
+	"time"
 
 	"go.opentelemetry.io/otel/trace"
 
@@ -13,21 +14,26 @@ import (
 )
 
 type PriceQuerier struct {
-	Config    *config.Config
-	Coingecko *coingeckoPkg.Coingecko
-	Logger    zerolog.Logger
-	Tracer    trace.Tracer
+	Config        *config.Config
+	PriceProvider types.PriceProvider
+	Logger        zerolog.Logger
+	State         *state.State
+	Tracer        trace.Tracer
 }
 
 func NewPriceQuerier(
 	config *config.Config,
-	coingecko *coingeckoPkg.Coingecko,
+	priceProvider types.PriceProvider,
+	appState *state.State,
+	logger zerolog.Logger,
 	tracer trace.Tracer,
 ) *PriceQuerier {
 	return &PriceQuerier{
-		Config:    config,
-		Coingecko: coingecko,
-		Tracer:    tracer,
+		Config:        config,
+		PriceProvider: priceProvider,
+		Logger:        logger.With().Str("component", "price_querier").Logger(),
+		State:         appState,
+		Tracer:        tracer,
 	}
 }
 
@@ -40,26 +46,198 @@ func (q *PriceQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector,
 			Name: "cosmos_wallets_exporter_price",
 			Help: "A price of 1 token",
 		},
-		[]string{"chain", "denom"},
+		[]string{"chain", "denom", "currency", "source"},
 	)
 
-	currenciesList := q.Config.GetCoingeckoCurrencies()
-	currenciesRates, queryInfo := q.Coingecko.FetchPrices(currenciesList, childCtx)
+	priceAgeGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_price_age_seconds",
+			Help: "Time since the token price was fetched (in seconds)",
+		},
+		[]string{"chain", "denom", "currency", "source"},
+	)
+
+	walletValueGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_wallet_value",
+			Help: "A total value of all wallet tokens that have a price (in fiat currency)",
+		},
+		[]string{"chain", "address", "name", "group", "currency"},
+	)
+
+	groupValueGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_group_value",
+			Help: "A total value of all tokens of wallets in a group across all chains (in fiat currency)",
+		},
+		[]string{"group", "currency"},
+	)
+
+	chainValueGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_chain_value",
+			Help: "A total value of all tokens of wallets on a chain (in fiat currency)",
+		},
+		[]string{"chain", "currency"},
+	)
+
+	denoms := q.getPriceDenoms()
+
+	prices, queryInfos, err := q.PriceProvider.GetPrices(denoms, q.Config.FiatCurrencies, childCtx)
+	if err != nil {
+		q.Logger.Error().Err(err).Msg("Could not get prices")
+	}
+
+	for _, denom := range denoms {
+		chain, _ := q.Config.FindChainByName(denom.Chain)
+
+		for _, fiatCurrency := range q.Config.FiatCurrencies {
+			price, found := prices.Get(chain.Name, denom.DenomInfo.Denom, fiatCurrency)
+			if !found {
+				continue
+			}
+
+			labels := prometheus.Labels{
+				"chain":    chain.Name,
+				"denom":    ResolveDenom(q.Config, q.State, *chain, denom.DenomInfo.Denom).GetName(),
+				"currency": fiatCurrency,
+				"source":   price.Source,
+			}
+
+			priceGauge.With(labels).Set(price.Value)
+
+			if !price.UpdatedAt.IsZero() {
+				priceAgeGauge.With(labels).Set(time.Since(price.UpdatedAt).Seconds())
+			}
+		}
+	}
+
+	if prices != nil {
+		q.setValueMetrics(prices, walletValueGauge, groupValueGauge, chainValueGauge)
+	}
+
+	return []prometheus.Collector{
+		priceGauge,
+		walletValueGauge,
+		groupValueGauge,
+		chainValueGauge,
+		priceAgeGauge,
+	}, queryInfos
+}
+
+// getPriceDenoms returns the denoms to fetch prices for: the configured ones, and the ones
+// taken from chain-registry only if any wallet has them, as asset lists have hundreds of denoms.
+func (q *PriceQuerier) getPriceDenoms() []types.ChainDenom {
+	denoms := []types.ChainDenom{}
 
 	for _, chain := range q.Config.Chains {
-		for _, denom := range chain.Denoms {
-			if denom.CoingeckoCurrency == "" {
+		for _, denom := range chain.GetDenoms() {
+			denoms = append(denoms, types.ChainDenom{Chain: chain.Name, DenomInfo: denom})
+		}
+	}
+
+	added := map[string]map[string]bool{}
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
 				continue
 			}
 
-			if price, ok := currenciesRates[denom.CoingeckoCurrency]; ok {
-				priceGauge.With(prometheus.Labels{
-					"chain": chain.Name,
-					"denom": denom.GetName(),
-				}).Set(price)
+			for _, balance := range entry.GetValueTokenBalances() {
+				denom := resolveConfigDenom(q.Config, q.State, chain, balance.Denom)
+				if denom.DenomInfo == nil {
+					continue
+				}
+
+				priceChain, found := q.Config.FindChainByName(denom.PriceChain)
+				if !found || !priceChain.IsRegistryDenom(denom.DenomInfo.Denom) {
+					continue
+				}
+
+				if added[priceChain.Name] == nil {
+					added[priceChain.Name] = map[string]bool{}
+				}
+
+				if added[priceChain.Name][denom.DenomInfo.Denom] {
+					continue
+				}
+
+				added[priceChain.Name][denom.DenomInfo.Denom] = true
+				denoms = append(denoms, types.ChainDenom{Chain: priceChain.Name, DenomInfo: *denom.DenomInfo})
 			}
 		}
 	}
 
-	return []prometheus.Collector{priceGauge}, []types.QueryInfo{queryInfo}
+	return denoms
+}
+
+func (q *PriceQuerier) setValueMetrics(
+	prices types.Prices,
+	walletValueGauge *prometheus.GaugeVec,
+	groupValueGauge *prometheus.GaugeVec,
+	chainValueGauge *prometheus.GaugeVec,
+) {
+	for _, fiatCurrency := range q.Config.FiatCurrencies {
+		groupValues := map[string]float64{}
+
+		for _, chain := range q.Config.Chains {
+			chainValue := 0.0
+
+			for _, wallet := range chain.Wallets {
+				entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+				if !found || entry.UpdatedAt.IsZero() {
+					continue
+				}
+
+				walletValue := 0.0
+
+				for _, balance := range entry.GetValueTokenBalances() {
+					denom := ResolveDenom(q.Config, q.State, chain, balance.Denom)
+
+					price, found := denom.GetPrice(prices, fiatCurrency)
+					if !found {
+						continue
+					}
+
+					amount, err := denom.GetAmount(balance)
+					if err != nil {
+						q.Logger.Error().
+							Err(err).
+							Str("chain", chain.Name).
+							Str("address", wallet.Address).
+							Str("denom", balance.Denom).
+							Msg("Could not convert wallet amount")
+						continue
+					}
+
+					walletValue += amount * price.Value
+				}
+
+				walletValueGauge.With(prometheus.Labels{
+					"chain":    chain.Name,
+					"address":  wallet.Address,
+					"name":     wallet.Name,
+					"group":    wallet.Group,
+					"currency": fiatCurrency,
+				}).Set(walletValue)
+
+				chainValue += walletValue
+				groupValues[wallet.Group] += walletValue
+			}
+
+			chainValueGauge.With(prometheus.Labels{
+				"chain":    chain.Name,
+				"currency": fiatCurrency,
+			}).Set(chainValue)
+		}
+
+		for group, groupValue := range groupValues {
+			groupValueGauge.With(prometheus.Labels{
+				"group":    group,
+				"currency": fiatCurrency,
+			}).Set(groupValue)
+		}
+	}
 }
@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"main/assets"
-	coingeckoPkg "main/pkg/coingecko"
 	configPkg "main/pkg/config"
 	loggerPkg "main/pkg/logger"
+	pricesPkg "main/pkg/prices"
+	statePkg "main/pkg/state"
 	"main/pkg/tracing"
+	"main/pkg/types"
 	"testing"
+	"time"
 
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
 	"github.com/jarcoal/httpmock"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
@@ -27,22 +32,26 @@ func TestPriceQuerierFail(t *testing.T) {
 		httpmock.NewErrorResponder(errors.New("custom error")),
 	)
 
-	config := &configPkg.Config{Chains: []configPkg.Chain{{
-		Name:   "chain",
-		Denoms: []configPkg.DenomInfo{{Denom: "atom", CoingeckoCurrency: "cosmos"}},
-	}}}
+	config := &configPkg.Config{
+		FiatCurrencies: []string{"usd"},
+		Chains: []configPkg.Chain{{
+			Name:   "chain",
+			Denoms: []configPkg.DenomInfo{{Denom: "atom", CoingeckoCurrency: "cosmos"}},
+		}},
+	}
 
 	tracer := tracing.InitNoopTracer()
 	logger := loggerPkg.GetNopLogger()
-	coingecko := coingeckoPkg.NewCoingecko(config, *logger, tracer)
-	querier := NewPriceQuerier(config, coingecko, tracer)
+	coingecko := pricesPkg.NewCoingecko(config.CoingeckoConfig, *logger, tracer)
+	querier := NewPriceQuerier(config, coingecko, statePkg.NewState(), *logger, tracer)
 
 	metrics, queries := querier.GetMetrics(context.Background())
 	assert.Len(t, queries, 1)
 	assert.False(t, queries[0].Success)
 
-	assert.Len(t, metrics, 1)
+	assert.Len(t, metrics, 5)
 	assert.Zero(t, testutil.CollectAndCount(metrics[0]))
+	assert.Zero(t, testutil.CollectAndCount(metrics[1]))
 }
 
 //nolint:paralleltest // disabled due to httpmock usage
@@ -56,32 +65,293 @@ func TestPriceQuerierOk(t *testing.T) {
 		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coingecko.json")),
 	)
 
-	config := &configPkg.Config{Chains: []configPkg.Chain{{
-		Name: "chain",
-		Denoms: []configPkg.DenomInfo{
-			{Denom: "atom", CoingeckoCurrency: "cosmos"},
-			{Denom: "random", CoingeckoCurrency: "random"},
-			{Denom: "unknown"},
-		},
-	}}}
+	config := &configPkg.Config{
+		FiatCurrencies: []string{"usd"},
+		Chains: []configPkg.Chain{{
+			Name: "chain",
+			Denoms: []configPkg.DenomInfo{
+				{Denom: "atom", CoingeckoCurrency: "cosmos"},
+				{Denom: "random", CoingeckoCurrency: "random"},
+				{Denom: "unknown"},
+			},
+		}},
+	}
 
 	tracer := tracing.InitNoopTracer()
 	logger := loggerPkg.GetNopLogger()
-	coingecko := coingeckoPkg.NewCoingecko(config, *logger, tracer)
-	querier := NewPriceQuerier(config, coingecko, tracer)
+	coingecko := pricesPkg.NewCoingecko(config.CoingeckoConfig, *logger, tracer)
+	cache := pricesPkg.NewCache(coingecko, configPkg.PriceCacheConfig{
+		TTL:          time.Minute,
+		MaxStaleness: time.Hour,
+	}, *logger, tracer)
+	querier := NewPriceQuerier(config, cache, statePkg.NewState(), *logger, tracer)
 
 	metrics, queries := querier.GetMetrics(context.Background())
 	assert.Len(t, queries, 1)
 	assert.True(t, queries[0].Success)
 
-	assert.Len(t, metrics, 1)
+	assert.Len(t, metrics, 5)
 
 	pricesMetric, ok := metrics[0].(*prometheus.GaugeVec)
 	assert.True(t, ok)
 
 	assert.InDelta(t, 1, testutil.CollectAndCount(pricesMetric), 0.001)
 	assert.InDelta(t, 5.84, testutil.ToFloat64(pricesMetric.With(prometheus.Labels{
-		"chain": "chain",
-		"denom": "atom",
+		"chain":    "chain",
+		"denom":    "atom",
+		"currency": "usd",
+		"source":   "coingecko",
 	})), 0.01)
+
+	priceAgeMetric, ok := metrics[4].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(priceAgeMetric))
+	assert.Less(t, testutil.ToFloat64(priceAgeMetric.With(prometheus.Labels{
+		"chain":    "chain",
+		"denom":    "atom",
+		"currency": "usd",
+		"source":   "coingecko",
+	})), 1.0)
+
+	// neither the cached price nor the ones that were not returned are queried again within TTL
+	metrics, queries = querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics[0]))
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestPriceQuerierValues(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://api.coingecko.com/api/v3/simple/price?ids=cosmos,sentinel&vs_currencies=usd,eur",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("coingecko-multiple.json")),
+	)
+
+	config := &configPkg.Config{
+		FiatCurrencies: []string{"usd", "eur"},
+		Chains: []configPkg.Chain{
+			{
+				Name: "cosmos",
+				Denoms: []configPkg.DenomInfo{
+					{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6), CoingeckoCurrency: "cosmos"},
+				},
+				Wallets: []configPkg.Wallet{
+					{Address: "cosmos1", Name: "cosmos1", Group: "restake"},
+					{Address: "cosmos2", Name: "cosmos2", Group: "validator"},
+					{Address: "cosmos3", Name: "cosmos3", Group: "validator"},
+				},
+			},
+			{
+				Name: "sentinel",
+				Denoms: []configPkg.DenomInfo{
+					{Denom: "udvpn", DisplayDenom: "dvpn", DenomExponent: null.IntFrom(6), CoingeckoCurrency: "sentinel"},
+				},
+				Wallets: []configPkg.Wallet{
+					{Address: "sent1", Name: "sent1", Group: "restake"},
+				},
+			},
+		},
+	}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:  "cosmos",
+		Wallet: config.Chains[0].Wallets[0],
+		Balances: types.Balances{
+			{Denom: "uatom", Amount: math.LegacyNewDec(10000000)},
+			{Denom: "ustake", Amount: math.LegacyNewDec(10000000)},
+		},
+		UpdatedAt: time.Now(),
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "cosmos",
+		Wallet:    config.Chains[0].Wallets[1],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(1000000)}},
+		UpdatedAt: time.Now(),
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "sentinel",
+		Wallet:    config.Chains[1].Wallets[0],
+		Balances:  types.Balances{{Denom: "udvpn", Amount: math.LegacyNewDec(1000000000)}},
+		UpdatedAt: time.Now(),
+	})
+
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	coingecko := pricesPkg.NewCoingecko(config.CoingeckoConfig, *logger, tracer)
+	querier := NewPriceQuerier(config, coingecko, state, *logger, tracer)
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Len(t, queries, 1)
+	assert.True(t, queries[0].Success)
+	assert.Len(t, metrics, 5)
+
+	pricesMetric, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 4, testutil.CollectAndCount(pricesMetric))
+	assert.InDelta(t, 5.4, testutil.ToFloat64(pricesMetric.With(prometheus.Labels{
+		"chain":    "cosmos",
+		"denom":    "atom",
+		"currency": "eur",
+		"source":   "coingecko",
+	})), 0.001)
+
+	walletValue, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 6, testutil.CollectAndCount(walletValue))
+	assert.InDelta(t, 58.4, testutil.ToFloat64(walletValue.With(prometheus.Labels{
+		"chain":    "cosmos",
+		"address":  "cosmos1",
+		"name":     "cosmos1",
+		"group":    "restake",
+		"currency": "usd",
+	})), 0.001)
+	assert.InDelta(t, 0.9, testutil.ToFloat64(walletValue.With(prometheus.Labels{
+		"chain":    "sentinel",
+		"address":  "sent1",
+		"name":     "sent1",
+		"group":    "restake",
+		"currency": "eur",
+	})), 0.001)
+
+	groupValue, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 4, testutil.CollectAndCount(groupValue))
+	assert.InDelta(t, 59.4, testutil.ToFloat64(groupValue.With(prometheus.Labels{
+		"group":    "restake",
+		"currency": "usd",
+	})), 0.001)
+	assert.InDelta(t, 5.84, testutil.ToFloat64(groupValue.With(prometheus.Labels{
+		"group":    "validator",
+		"currency": "usd",
+	})), 0.001)
+
+	chainValue, ok := metrics[3].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 4, testutil.CollectAndCount(chainValue))
+	assert.InDelta(t, 64.24, testutil.ToFloat64(chainValue.With(prometheus.Labels{
+		"chain":    "cosmos",
+		"currency": "usd",
+	})), 0.001)
+	assert.InDelta(t, 0.9, testutil.ToFloat64(chainValue.With(prometheus.Labels{
+		"chain":    "sentinel",
+		"currency": "eur",
+	})), 0.001)
+}
+
+func TestPriceQuerierEVMBalanceValue(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{
+		FiatCurrencies: []string{"usd"},
+		Chains: []configPkg.Chain{{
+			Name:           "evmos",
+			EVMNativeDenom: "aevmos",
+			Denoms: []configPkg.DenomInfo{{
+				Denom:         "aevmos",
+				DisplayDenom:  "evmos",
+				DenomExponent: null.IntFrom(18),
+				StaticPrices:  map[string]float64{"usd": 2},
+			}},
+			Wallets: []configPkg.Wallet{{Address: "evmos1", Name: "name", Group: "group"}},
+		}},
+	}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:       "evmos",
+		Wallet:      config.Chains[0].Wallets[0],
+		Balances:    types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("3000000000000000000")}},
+		EVMBalances: types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("3000000000000000000")}},
+		UpdatedAt:   time.Now(),
+	})
+
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	querier := NewPriceQuerier(config, pricesPkg.NewStatic(), state, *logger, tracer)
+
+	metrics, _ := querier.GetMetrics(context.Background())
+
+	// the EVM balance is the same funds as the bank one, so it's only counted once
+	walletValue, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 6, testutil.ToFloat64(walletValue.With(prometheus.Labels{
+		"chain":    "evmos",
+		"address":  "evmos1",
+		"name":     "name",
+		"group":    "group",
+		"currency": "usd",
+	})), 0.001)
+
+	chainValue, ok := metrics[3].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 6, testutil.ToFloat64(chainValue.With(prometheus.Labels{
+		"chain":    "evmos",
+		"currency": "usd",
+	})), 0.001)
+}
+
+func TestPriceQuerierRegistryDenoms(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{
+		FiatCurrencies: []string{"usd"},
+		Chains: []configPkg.Chain{{
+			Name: "cosmos",
+			RegistryDenoms: map[string]configPkg.DenomInfo{
+				"uatom": {
+					Denom:         "uatom",
+					DisplayDenom:  "atom",
+					DenomExponent: null.IntFrom(6),
+					StaticPrices:  map[string]float64{"usd": 10},
+				},
+				"uother": {
+					Denom:         "uother",
+					DisplayDenom:  "other",
+					DenomExponent: null.IntFrom(6),
+					StaticPrices:  map[string]float64{"usd": 1},
+				},
+			},
+			Wallets: []configPkg.Wallet{{Address: "cosmos1", Name: "name", Group: "group"}},
+		}},
+	}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "cosmos",
+		Wallet:    config.Chains[0].Wallets[0],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(2000000)}},
+		UpdatedAt: time.Now(),
+	})
+
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	querier := NewPriceQuerier(config, pricesPkg.NewStatic(), state, *logger, tracer)
+
+	metrics, _ := querier.GetMetrics(context.Background())
+
+	// only the chain-registry denom the wallet has gets its price fetched
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics[0]))
+
+	price, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 10, testutil.ToFloat64(price.With(prometheus.Labels{
+		"chain":    "cosmos",
+		"denom":    "atom",
+		"currency": "usd",
+		"source":   "static",
+	})), 0.001)
+
+	walletValue, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.InDelta(t, 20, testutil.ToFloat64(walletValue.With(prometheus.Labels{
+		"chain":    "cosmos",
+		"address":  "cosmos1",
+		"name":     "name",
+		"group":    "group",
+		"currency": "usd",
+	})), 0.001)
 }
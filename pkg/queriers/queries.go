@@ -44,6 +44,14 @@ func (q *QueriesQuerier) GetMetrics() ([]prometheus.Collector, []types.QueryInfo
 		[]string{"chain", "url"},
 	)
 
+	pagesGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_pages_fetched",
+			Help: "A count of pages fetched by list queries",
+		},
+		[]string{"chain"},
+	)
+
 	// so we would have this metrics even if there are no requests
 	for _, chain := range q.Config.Chains {
 		successGauge.With(prometheus.Labels{
@@ -53,6 +61,10 @@ func (q *QueriesQuerier) GetMetrics() ([]prometheus.Collector, []types.QueryInfo
 		errorGauge.With(prometheus.Labels{
 			"chain": chain.Name,
 		}).Set(0)
+
+		pagesGauge.With(prometheus.Labels{
+			"chain": chain.Name,
+		}).Set(0)
 	}
 
 	for _, query := range q.Infos {
@@ -61,6 +73,10 @@ func (q *QueriesQuerier) GetMetrics() ([]prometheus.Collector, []types.QueryInfo
 			"url":   query.URL,
 		}).Set(query.Duration.Seconds())
 
+		pagesGauge.With(prometheus.Labels{
+			"chain": query.Chain,
+		}).Add(float64(query.Pages))
+
 		if query.Success {
 			successGauge.With(prometheus.Labels{
 				"chain": query.Chain,
@@ -76,5 +92,6 @@ func (q *QueriesQuerier) GetMetrics() ([]prometheus.Collector, []types.QueryInfo
 		successGauge,
 		errorGauge,
 		timingsGauge,
+		pagesGauge,
 	}, []types.QueryInfo{}
 }
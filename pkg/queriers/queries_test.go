@@ -17,14 +17,14 @@ func TestQueriesQuerier(t *testing.T) {
 	config := &configPkg.Config{Chains: []configPkg.Chain{{Name: "chain"}, {Name: "chain2"}}}
 
 	queries := []types.QueryInfo{
-		{Chain: "chain", Success: true, URL: "url1", Duration: 5 * time.Second},
-		{Chain: "chain", Success: false, URL: "url2", Duration: 3 * time.Second},
+		{Chain: "chain", Success: true, URL: "url1", Duration: 5 * time.Second, Pages: 3},
+		{Chain: "chain", Success: false, URL: "url2", Duration: 3 * time.Second, Pages: 1},
 	}
 
 	querier := NewQueriesQuerier(config, queries)
 	metrics, queryInfos := querier.GetMetrics()
 	assert.Empty(t, queryInfos)
-	assert.Len(t, metrics, 3)
+	assert.Len(t, metrics, 4)
 
 	successGauge, ok := metrics[0].(*prometheus.GaugeVec)
 	assert.True(t, ok)
@@ -57,4 +57,11 @@ func TestQueriesQuerier(t *testing.T) {
 		"chain": "chain",
 		"url":   "url2",
 	})), 0.01)
+
+	pagesGauge, ok := metrics[3].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 2, testutil.CollectAndCount(pagesGauge))
+	assert.InEpsilon(t, float64(4), testutil.ToFloat64(pagesGauge.With(prometheus.Labels{
+		"chain": "chain",
+	})), 0.01)
 }
@@ -0,0 +1,73 @@
+package queriers
+
+import (
+	"context"
+	"errors"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type RewardsQuerier struct {
+	Config *config.Config
+	Logger zerolog.Logger
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewRewardsQuerier(
+	config *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *RewardsQuerier {
+	return &RewardsQuerier{
+		Config: config,
+		Logger: logger.With().Str("component", "rewards_querier").Logger(),
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *RewardsQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying rewards metrics")
+	defer span.End()
+
+	rewardsGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_rewards",
+			Help: "A wallet outstanding delegator rewards (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	commissionGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_commission",
+			Help: "A validator accumulated commission (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
+				continue
+			}
+
+			err := errors.Join(
+				setBalancesGauge(rewardsGauge, q.Config, q.State, chain, wallet, entry.Rewards),
+				setBalancesGauge(commissionGauge, q.Config, q.State, chain, wallet, entry.Commission),
+			)
+			logAmountsError(q.Logger, chain, wallet, err)
+		}
+	}
+
+	return []prometheus.Collector{rewardsGauge, commissionGauge}, []types.QueryInfo{}
+}
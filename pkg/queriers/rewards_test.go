@@ -0,0 +1,64 @@
+package queriers
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewardsQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{
+			{Address: "address", Name: "name", Group: "group", ValidatorAddress: "valoper"},
+			{Address: "address2", Name: "name2", Group: "group"},
+		},
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:      "chain",
+		Wallet:     config.Chains[0].Wallets[0],
+		Rewards:    types.Balances{{Denom: "uatom", Amount: math.LegacyMustNewDecFromStr("2000.123")}},
+		Commission: types.Balances{{Denom: "uatom", Amount: math.LegacyMustNewDecFromStr("123456789.5")}},
+	})
+
+	querier := NewRewardsQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 2)
+
+	labels := prometheus.Labels{
+		"chain":      "chain",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+		"denom":      "atom",
+		"base_denom": "uatom",
+		"ibc_path":   "",
+	}
+
+	rewards, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(rewards))
+	assert.InDelta(t, 0.002000123, testutil.ToFloat64(rewards.With(labels)), 0.0000001)
+
+	commission, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(commission))
+	assert.InDelta(t, 123.4567895, testutil.ToFloat64(commission.With(labels)), 0.0000001)
+}
@@ -0,0 +1,82 @@
+package queriers
+
+import (
+	"context"
+	"errors"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type StakingQuerier struct {
+	Config *config.Config
+	Logger zerolog.Logger
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewStakingQuerier(
+	config *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *StakingQuerier {
+	return &StakingQuerier{
+		Config: config,
+		Logger: logger.With().Str("component", "staking_querier").Logger(),
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *StakingQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying staking metrics")
+	defer span.End()
+
+	delegatedGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_delegated",
+			Help: "A wallet delegated balance (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	unbondingGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_unbonding",
+			Help: "A wallet unbonding balance (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	redelegatingGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_redelegating",
+			Help: "A wallet redelegating balance (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
+				continue
+			}
+
+			err := errors.Join(
+				setBalancesGauge(delegatedGauge, q.Config, q.State, chain, wallet, entry.Delegations),
+				setBalancesGauge(unbondingGauge, q.Config, q.State, chain, wallet, entry.Unbondings),
+				setBalancesGauge(redelegatingGauge, q.Config, q.State, chain, wallet, entry.Redelegations),
+			)
+			logAmountsError(q.Logger, chain, wallet, err)
+		}
+	}
+
+	return []prometheus.Collector{delegatedGauge, unbondingGauge, redelegatingGauge}, []types.QueryInfo{}
+}
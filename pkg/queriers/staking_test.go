@@ -0,0 +1,70 @@
+package queriers
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStakingQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{
+			{Address: "address", Name: "name", Group: "group"},
+			{Address: "address2", Name: "name2", Group: "group"},
+		},
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:         "chain",
+		Wallet:        config.Chains[0].Wallets[0],
+		Delegations:   types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(3000000)}},
+		Unbondings:    types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(750000)}},
+		Redelegations: types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(300000)}},
+	})
+
+	querier := NewStakingQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 3)
+
+	labels := prometheus.Labels{
+		"chain":      "chain",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+		"denom":      "atom",
+		"base_denom": "uatom",
+		"ibc_path":   "",
+	}
+
+	delegated, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(delegated))
+	assert.InDelta(t, 3, testutil.ToFloat64(delegated.With(labels)), 0.001)
+
+	unbonding, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(unbonding))
+	assert.InDelta(t, 0.75, testutil.ToFloat64(unbonding.With(labels)), 0.001)
+
+	redelegating, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(redelegating))
+	assert.InDelta(t, 0.3, testutil.ToFloat64(redelegating.With(labels)), 0.001)
+}
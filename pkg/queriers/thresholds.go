@@ -0,0 +1,159 @@
+package queriers
+
+import (
+	"context"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+	"main/pkg/utils"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type ThresholdsQuerier struct {
+	Config *config.Config
+	Logger zerolog.Logger
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewThresholdsQuerier(
+	config *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *ThresholdsQuerier {
+	return &ThresholdsQuerier{
+		Config: config,
+		Logger: logger.With().Str("component", "thresholds_querier").Logger(),
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *ThresholdsQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying thresholds metrics")
+	defer span.End()
+
+	thresholdGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_balance_threshold",
+			Help: "A wallet balance threshold (in tokens), either min or warn one",
+		},
+		[]string{"chain", "address", "name", "group", "denom", "level"},
+	)
+
+	belowThresholdGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_below_threshold",
+			Help: "Whether a wallet balance is below its threshold, either min or warn one",
+		},
+		[]string{"chain", "address", "name", "group", "denom", "level"},
+	)
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			thresholds := q.Config.GetWalletThresholds(wallet)
+			if len(thresholds) == 0 {
+				continue
+			}
+
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+
+			for _, threshold := range thresholds {
+				labels := prometheus.Labels{
+					"chain":   chain.Name,
+					"address": wallet.Address,
+					"name":    wallet.Name,
+					"group":   wallet.Group,
+					"denom":   threshold.Denom,
+					"level":   threshold.Level,
+				}
+
+				thresholdGauge.With(labels).Set(threshold.Value)
+
+				// a wallet that wasn't fetched yet is not known to be below the threshold
+				if !found || entry.UpdatedAt.IsZero() {
+					continue
+				}
+
+				amount, err := GetWalletDenomAmount(q.Config, q.State, chain, entry, threshold.Denom)
+				if err != nil {
+					q.Logger.Error().
+						Err(err).
+						Str("chain", chain.Name).
+						Str("address", wallet.Address).
+						Str("denom", threshold.Denom).
+						Msg("Could not get wallet amount to compare with the threshold")
+					continue
+				}
+
+				belowThresholdGauge.With(labels).Set(utils.BoolToFloat64(amount < threshold.Value))
+			}
+		}
+	}
+
+	return []prometheus.Collector{thresholdGauge, belowThresholdGauge}, []types.QueryInfo{}
+}
+
+// GetWalletDenomAmount returns the wallet balance of the denom, matched by display denom or base denom,
+// in tokens, or 0 if the wallet has no such balance, as zero balances are not returned by the node.
+// Balances of the same denom are summed within a token type only, as, for example,
+// on Cosmos EVM chains the native EVM balance is the same funds as the bank one.
+func GetWalletDenomAmount(
+	appConfig *config.Config,
+	appState *state.State,
+	chain config.Chain,
+	entry types.WalletBalanceEntry,
+	denomName string,
+) (float64, error) {
+	for _, tokenBalances := range getTokenTypeBalances(entry) {
+		matched := false
+		total := 0.0
+
+		for _, balance := range tokenBalances.balances {
+			denom := ResolveTokenDenom(appConfig, appState, chain, balance.Denom, tokenBalances.tokenType)
+			if !isSameDenom(denom, denomName) {
+				continue
+			}
+
+			amount, err := denom.GetAmount(balance)
+			if err != nil {
+				return 0, err
+			}
+
+			matched = true
+			total += amount
+		}
+
+		if matched {
+			return total, nil
+		}
+	}
+
+	return 0, nil
+}
+
+type tokenTypeBalances struct {
+	tokenType string
+	balances  types.Balances
+}
+
+// getTokenTypeBalances returns the wallet balances of each token type,
+// in the order they are matched against a denom.
+func getTokenTypeBalances(entry types.WalletBalanceEntry) []tokenTypeBalances {
+	return []tokenTypeBalances{
+		{types.TokenTypeNative, entry.Balances},
+		{types.TokenTypeCW20, entry.CW20Balances},
+		{types.TokenTypeEVM, entry.EVMBalances},
+		{types.TokenTypeERC20, entry.ERC20Balances},
+	}
+}
+
+// isSameDenom returns whether the denom name is the denom display denom, base denom or denom itself.
+func isSameDenom(denom ResolvedDenom, denomName string) bool {
+	return denom.GetName() == denomName || denom.BaseDenom == denomName || denom.Denom == denomName
+}
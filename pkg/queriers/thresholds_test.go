@@ -0,0 +1,148 @@
+package queriers
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThresholdsQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{
+		Groups: map[string]configPkg.GroupConfig{
+			"validator": {BalanceThresholds: configPkg.BalanceThresholds{
+				MinBalance:  map[string]float64{"atom": 1},
+				WarnBalance: map[string]float64{"atom": 5},
+			}},
+		},
+		Chains: []configPkg.Chain{{
+			Name: "chain",
+			Wallets: []configPkg.Wallet{
+				{Address: "address", Name: "name", Group: "validator"},
+				{
+					Address: "address2",
+					Name:    "name2",
+					Group:   "validator",
+					BalanceThresholds: configPkg.BalanceThresholds{
+						WarnBalance: map[string]float64{"atom": 2},
+					},
+				},
+				{Address: "address3", Name: "name3", Group: "validator"},
+				{Address: "address4", Name: "name4", Group: "validator"},
+				{Address: "address5", Name: "name5", Group: "other"},
+			},
+			Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+		}},
+	}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Wallet:    config.Chains[0].Wallets[0],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(3000000)}},
+		UpdatedAt: time.Now(),
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Wallet:    config.Chains[0].Wallets[1],
+		Balances:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(3000000)}},
+		UpdatedAt: time.Now(),
+	})
+	// no uatom balance at all
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Wallet:    config.Chains[0].Wallets[2],
+		Balances:  types.Balances{{Denom: "ustake", Amount: math.LegacyNewDec(3000000)}},
+		UpdatedAt: time.Now(),
+	})
+
+	querier := NewThresholdsQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 2)
+
+	// 4 wallets with group thresholds, none for the wallet without thresholds
+	assert.Equal(t, 8, testutil.CollectAndCount(metrics[0]))
+	// not fetched yet wallet is skipped
+	assert.Equal(t, 6, testutil.CollectAndCount(metrics[1]))
+
+	getLabels := func(address string, name string, level string) prometheus.Labels {
+		return prometheus.Labels{
+			"chain":   "chain",
+			"address": address,
+			"name":    name,
+			"group":   "validator",
+			"denom":   "atom",
+			"level":   level,
+		}
+	}
+
+	thresholdGauge := metrics[0].(*prometheus.GaugeVec)
+	belowGauge := metrics[1].(*prometheus.GaugeVec)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(thresholdGauge.With(getLabels("address", "name", "min"))), 0.01)
+	assert.InDelta(t, 5, testutil.ToFloat64(thresholdGauge.With(getLabels("address", "name", "warn"))), 0.01)
+	assert.InDelta(t, 0, testutil.ToFloat64(belowGauge.With(getLabels("address", "name", "min"))), 0.01)
+	assert.InDelta(t, 1, testutil.ToFloat64(belowGauge.With(getLabels("address", "name", "warn"))), 0.01)
+
+	// wallet-level threshold overrides the group one
+	assert.InDelta(t, 2, testutil.ToFloat64(thresholdGauge.With(getLabels("address2", "name2", "warn"))), 0.01)
+	assert.InDelta(t, 0, testutil.ToFloat64(belowGauge.With(getLabels("address2", "name2", "warn"))), 0.01)
+
+	assert.InDelta(t, 1, testutil.ToFloat64(belowGauge.With(getLabels("address3", "name3", "min"))), 0.01)
+	assert.InDelta(t, 1, testutil.ToFloat64(belowGauge.With(getLabels("address3", "name3", "warn"))), 0.01)
+}
+
+func TestThresholdsQuerierTokenTypes(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "evmos",
+		Wallets: []configPkg.Wallet{{
+			Address: "address",
+			BalanceThresholds: configPkg.BalanceThresholds{
+				MinBalance: map[string]float64{"evmos": 2, "aevmos": 2},
+			},
+		}},
+		Denoms: []configPkg.DenomInfo{{Denom: "aevmos", DisplayDenom: "evmos", DenomExponent: null.IntFrom(18)}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:       "evmos",
+		Wallet:      config.Chains[0].Wallets[0],
+		Balances:    types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("1500000000000000000")}},
+		EVMBalances: types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("1500000000000000000")}},
+		UpdatedAt:   time.Now(),
+	})
+
+	querier := NewThresholdsQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+	metrics, _ := querier.GetMetrics(context.Background())
+
+	// the EVM balance is the same funds as the bank one, so it's not summed up,
+	// and both display and base denoms are matched
+	belowGauge := metrics[1].(*prometheus.GaugeVec)
+	for _, denom := range []string{"evmos", "aevmos"} {
+		assert.InDelta(t, 1, testutil.ToFloat64(belowGauge.With(prometheus.Labels{
+			"chain":   "evmos",
+			"address": "address",
+			"name":    "",
+			"group":   "",
+			"denom":   denom,
+			"level":   "min",
+		})), 0.01)
+	}
+}
@@ -0,0 +1,105 @@
+package queriers
+
+import (
+	"context"
+	"errors"
+	"main/pkg/config"
+	"main/pkg/state"
+	"main/pkg/types"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/rs/zerolog"
+)
+
+type VestingQuerier struct {
+	Config *config.Config
+	Logger zerolog.Logger
+	State  *state.State
+	Tracer trace.Tracer
+}
+
+func NewVestingQuerier(
+	config *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *VestingQuerier {
+	return &VestingQuerier{
+		Config: config,
+		Logger: logger.With().Str("component", "vesting_querier").Logger(),
+		State:  appState,
+		Tracer: tracer,
+	}
+}
+
+func (q *VestingQuerier) GetMetrics(ctx context.Context) ([]prometheus.Collector, []types.QueryInfo) {
+	_, span := q.Tracer.Start(ctx, "Querying vesting metrics")
+	defer span.End()
+
+	originalVestingGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_vesting_original",
+			Help: "A vesting account initial vesting amount (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	delegatedVestingGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_vesting_delegated",
+			Help: "A vesting account delegated vesting amount (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	lockedGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_vesting_locked",
+			Help: "A vesting account amount that is still locked (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	spendableGauge := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cosmos_wallets_exporter_spendable",
+			Help: "A wallet spendable balance (in tokens)",
+		},
+		WalletDenomLabels,
+	)
+
+	now := time.Now()
+
+	for _, chain := range q.Config.Chains {
+		for _, wallet := range chain.Wallets {
+			entry, found := q.State.GetWalletEntry(chain.Name, wallet.Address)
+			if !found {
+				continue
+			}
+
+			err := setBalancesGauge(spendableGauge, q.Config, q.State, chain, wallet, entry.Spendable)
+			logAmountsError(q.Logger, chain, wallet, err)
+
+			if entry.Account == nil || !entry.Account.IsVesting() {
+				continue
+			}
+
+			err = errors.Join(
+				setBalancesGauge(originalVestingGauge, q.Config, q.State, chain, wallet, entry.Account.BaseVestingAccount.OriginalVesting),
+				setBalancesGauge(delegatedVestingGauge, q.Config, q.State, chain, wallet, entry.Account.BaseVestingAccount.DelegatedVesting),
+				setBalancesGauge(lockedGauge, q.Config, q.State, chain, wallet, entry.Account.GetLockedCoins(now)),
+			)
+			logAmountsError(q.Logger, chain, wallet, err)
+		}
+	}
+
+	return []prometheus.Collector{
+		originalVestingGauge,
+		delegatedVestingGauge,
+		lockedGauge,
+		spendableGauge,
+	}, []types.QueryInfo{}
+}
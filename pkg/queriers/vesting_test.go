@@ -0,0 +1,88 @@
+package queriers
+
+import (
+	"context"
+	configPkg "main/pkg/config"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVestingQuerierOk(t *testing.T) {
+	t.Parallel()
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name: "chain",
+		Wallets: []configPkg.Wallet{
+			{Address: "address", Name: "name", Group: "group"},
+			{Address: "address2", Name: "name2", Group: "group"},
+		},
+		Denoms: []configPkg.DenomInfo{{Denom: "uatom", DisplayDenom: "atom", DenomExponent: null.IntFrom(6)}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:  "chain",
+		Wallet: config.Chains[0].Wallets[0],
+		Account: &types.Account{
+			Type: types.DelayedVestingAccountType,
+			BaseVestingAccount: &types.BaseVestingAccount{
+				OriginalVesting:  types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(1000000)}},
+				DelegatedVesting: types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(400000)}},
+				EndTime:          time.Now().Add(time.Hour).Unix(),
+			},
+		},
+		Spendable: types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(100000)}},
+	})
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:     "chain",
+		Wallet:    config.Chains[0].Wallets[1],
+		Account:   &types.Account{Type: "/cosmos.auth.v1beta1.BaseAccount"},
+		Spendable: types.Balances{{Denom: "uatom", Amount: math.LegacyNewDec(200000)}},
+	})
+
+	querier := NewVestingQuerier(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	metrics, queries := querier.GetMetrics(context.Background())
+	assert.Empty(t, queries)
+	assert.Len(t, metrics, 4)
+
+	labels := prometheus.Labels{
+		"chain":      "chain",
+		"address":    "address",
+		"name":       "name",
+		"group":      "group",
+		"denom":      "atom",
+		"base_denom": "uatom",
+		"ibc_path":   "",
+	}
+
+	original, ok := metrics[0].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(original))
+	assert.InDelta(t, 1, testutil.ToFloat64(original.With(labels)), 0.001)
+
+	delegated, ok := metrics[1].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(delegated))
+	assert.InDelta(t, 0.4, testutil.ToFloat64(delegated.With(labels)), 0.001)
+
+	locked, ok := metrics[2].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 1, testutil.CollectAndCount(locked))
+	assert.InDelta(t, 1, testutil.ToFloat64(locked.With(labels)), 0.001)
+
+	spendable, ok := metrics[3].(*prometheus.GaugeVec)
+	assert.True(t, ok)
+	assert.Equal(t, 2, testutil.CollectAndCount(spendable))
+	assert.InDelta(t, 0.1, testutil.ToFloat64(spendable.With(labels)), 0.001)
+}
@@ -0,0 +1,624 @@
+package scheduler
+
+import (
+	"context"
+	"main/pkg/abci"
+	"main/pkg/bech32"
+	"main/pkg/config"
+	"main/pkg/evm"
+	"main/pkg/grpc"
+	"main/pkg/queriers"
+	"main/pkg/state"
+	"main/pkg/tendermint"
+	"main/pkg/types"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rs/zerolog"
+)
+
+type Scheduler struct {
+	Config          *config.Config
+	Logger          zerolog.Logger
+	RPCs            []*tendermint.RPC
+	BalanceFetchers []types.BalanceFetcher
+	EVMClients      []*evm.Client
+	Listeners       []types.ChainQueryListener
+	State           *state.State
+	Tracer          trace.Tracer
+
+	stopChannel chan struct{}
+	stopOnce    sync.Once
+}
+
+func NewScheduler(
+	appConfig *config.Config,
+	appState *state.State,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *Scheduler {
+	rpcs := make([]*tendermint.RPC, len(appConfig.Chains))
+	balanceFetchers := make([]types.BalanceFetcher, len(appConfig.Chains))
+	evmClients := make([]*evm.Client, len(appConfig.Chains))
+
+	for index, chain := range appConfig.Chains {
+		rpcs[index] = tendermint.NewRPC(chain, logger, tracer)
+		balanceFetchers[index] = rpcs[index]
+
+		switch chain.BalancesTransport {
+		case config.BalancesTransportGRPC:
+			grpcClient, err := grpc.NewClient(chain, logger, tracer)
+			if err != nil {
+				logger.Panic().Err(err).Str("chain", chain.Name).Msg("Could not create gRPC client")
+			}
+
+			balanceFetchers[index] = grpcClient
+		case config.BalancesTransportABCI:
+			balanceFetchers[index] = abci.NewClient(chain, logger, tracer)
+		}
+
+		if chain.EVMRPCEndpoint != "" {
+			evmClients[index] = evm.NewClient(chain, logger, tracer)
+		}
+	}
+
+	return &Scheduler{
+		Config:          appConfig,
+		Logger:          logger.With().Str("component", "scheduler").Logger(),
+		RPCs:            rpcs,
+		BalanceFetchers: balanceFetchers,
+		EVMClients:      evmClients,
+		State:           appState,
+		Tracer:          tracer,
+		stopChannel:     make(chan struct{}),
+	}
+}
+
+// AddListener adds a listener to be notified after each chain poll.
+// Should be called before Start.
+func (s *Scheduler) AddListener(listener types.ChainQueryListener) {
+	s.Listeners = append(s.Listeners, listener)
+}
+
+func (s *Scheduler) Start() {
+	for index, chain := range s.Config.Chains {
+		go s.runChain(chain, s.RPCs[index], s.BalanceFetchers[index], s.EVMClients[index])
+	}
+}
+
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.stopChannel)
+	})
+}
+
+func (s *Scheduler) runChain(
+	chain config.Chain,
+	rpc *tendermint.RPC,
+	balanceFetcher types.BalanceFetcher,
+	evmClient *evm.Client,
+) {
+	s.Logger.Info().
+		Str("chain", chain.Name).
+		Dur("interval", chain.QueryInterval).
+		Msg("Starting polling chain")
+
+	s.QueryChain(context.Background(), chain, rpc, balanceFetcher, evmClient)
+
+	ticker := time.NewTicker(chain.QueryInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stopChannel:
+			return
+		case <-ticker.C:
+			s.QueryChain(context.Background(), chain, rpc, balanceFetcher, evmClient)
+		}
+	}
+}
+
+func (s *Scheduler) QueryChain(
+	ctx context.Context,
+	chain config.Chain,
+	rpc *tendermint.RPC,
+	balanceFetcher types.BalanceFetcher,
+	evmClient *evm.Client,
+) {
+	childCtx, span := s.Tracer.Start(ctx, "Polling chain")
+	span.SetAttributes(attribute.String("chain", chain.Name))
+	defer span.End()
+
+	queryInfos := s.queryDenomsMetadata(childCtx, chain, rpc)
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+
+	for _, wallet := range chain.Wallets {
+		wg.Add(1)
+		go func(wallet config.Wallet) {
+			defer wg.Done()
+
+			walletQueryInfos := s.queryWallet(childCtx, chain, wallet, rpc, balanceFetcher, evmClient)
+
+			mutex.Lock()
+			queryInfos = append(queryInfos, walletQueryInfos...)
+			mutex.Unlock()
+		}(wallet)
+	}
+
+	wg.Wait()
+
+	// the next poll goes to another endpoint
+	rpc.RotateEndpoints()
+
+	s.State.SetChainQueryInfos(chain.Name, queryInfos)
+	s.State.SetChainEndpoints(chain.Name, rpc.GetEndpointsStatus())
+
+	for _, listener := range s.Listeners {
+		listener.OnChainQueried(childCtx, chain)
+	}
+}
+
+// queryDenomsMetadata fetches the chain denoms metadata on the first poll and then
+// once per refresh interval, retrying on each poll if it fails.
+func (s *Scheduler) queryDenomsMetadata(
+	ctx context.Context,
+	chain config.Chain,
+	rpc *tendermint.RPC,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+
+	if !chain.DiscoverDenomsMetadata.Bool || len(rpc.Endpoints) == 0 {
+		return queryInfos
+	}
+
+	updatedAt := s.State.GetDenomsMetadataUpdatedAt(chain.Name)
+	if !updatedAt.IsZero() && time.Since(updatedAt) < chain.DenomsMetadataRefreshInterval {
+		return queryInfos
+	}
+
+	metadataResponse, queryInfo, err := rpc.GetDenomsMetadata(ctx)
+	queryInfos = append(queryInfos, queryInfo)
+
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Msg("Error querying denoms metadata")
+		return queryInfos
+	}
+
+	s.State.SetChainDenomsMetadata(chain.Name, metadataResponse.Metadatas)
+
+	return queryInfos
+}
+
+func (s *Scheduler) queryWallet(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	rpc *tendermint.RPC,
+	balanceFetcher types.BalanceFetcher,
+	evmClient *evm.Client,
+) []types.QueryInfo {
+	walletCtx, walletSpan := s.Tracer.Start(ctx, "Querying chain and wallet")
+	walletSpan.SetAttributes(attribute.String("chain", chain.Name))
+	walletSpan.SetAttributes(attribute.String("wallet", wallet.Address))
+	defer walletSpan.End()
+
+	entry, found := s.State.GetWalletEntry(chain.Name, wallet.Address)
+	if !found {
+		entry = types.WalletBalanceEntry{Chain: chain.Name}
+	}
+
+	entry.Wallet = wallet
+
+	// the entry is stored by the address from the config, while the queries
+	// to the Cosmos APIs need a bech32 address if it's a hex one
+	cosmosWallet := s.getCosmosWallet(chain, wallet)
+
+	balancesResponse, queryInfo, err := balanceFetcher.GetWalletBalances(cosmosWallet.Address, walletCtx)
+	queryInfos := []types.QueryInfo{queryInfo}
+	entry.Success = err == nil
+
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Msg("Error querying balance")
+	} else {
+		entry.Duration = queryInfo.Duration
+		entry.Balances = balancesResponse.Balances
+		entry.Endpoint = queryInfo.Endpoint
+		entry.UpdatedAt = time.Now()
+		entry.Height = queryInfo.Height
+	}
+
+	queryInfos = append(queryInfos, s.queryDenomTraces(walletCtx, chain, rpc, entry.Balances)...)
+	queryInfos = append(queryInfos, s.queryCW20Balances(walletCtx, chain, cosmosWallet, rpc, &entry)...)
+	queryInfos = append(queryInfos, s.queryEVMBalances(walletCtx, chain, wallet, evmClient, &entry)...)
+	queryInfos = append(queryInfos, s.queryStaking(walletCtx, chain, cosmosWallet, rpc, &entry)...)
+	queryInfos = append(queryInfos, s.queryDistribution(walletCtx, chain, cosmosWallet, rpc, &entry)...)
+	queryInfos = append(queryInfos, s.queryVesting(walletCtx, chain, cosmosWallet, rpc, &entry)...)
+
+	s.State.SetWalletEntry(entry)
+
+	if entry.Success && s.Config.BurnRateConfig.Enabled {
+		s.addBalanceSample(chain, entry)
+	}
+
+	return queryInfos
+}
+
+func (s *Scheduler) getCosmosWallet(chain config.Chain, wallet config.Wallet) config.Wallet {
+	if !bech32.IsHexAddress(wallet.Address) {
+		return wallet
+	}
+
+	address, err := bech32.HexToBech32(wallet.Address, chain.Bech32Prefix)
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Msg("Error converting hex address to bech32")
+		return wallet
+	}
+
+	cosmosWallet := wallet
+	cosmosWallet.Address = address
+	return cosmosWallet
+}
+
+// addBalanceSample converts the wallet balances to display denoms amounts once per poll,
+// so the spend rate calculation on each scrape doesn't have to resolve all the samples denoms.
+func (s *Scheduler) addBalanceSample(chain config.Chain, entry types.WalletBalanceEntry) {
+	sample, err := queriers.NewBalanceSample(s.Config, s.State, chain, entry)
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", entry.Wallet.Address).
+			Msg("Error converting wallet balances for the spend rate")
+	}
+
+	s.State.AddBalanceSample(chain.Name, entry.Wallet.Address, sample, s.Config.BurnRateConfig.Window)
+}
+
+// queryDenomTraces resolves IBC denoms that were not resolved before, as denom traces
+// never change, so they are cached for the app lifetime.
+func (s *Scheduler) queryDenomTraces(
+	ctx context.Context,
+	chain config.Chain,
+	rpc *tendermint.RPC,
+	balances types.Balances,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+
+	if !chain.ResolveIBCDenoms.Bool || len(rpc.Endpoints) == 0 {
+		return queryInfos
+	}
+
+	for _, balance := range balances {
+		if !types.IsIBCDenom(balance.Denom) {
+			continue
+		}
+
+		if _, found := s.State.GetDenomTrace(chain.Name, balance.Denom); found {
+			continue
+		}
+
+		traceResponse, queryInfo, err := rpc.GetDenomTrace(balance.Denom, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("denom", balance.Denom).
+				Msg("Error querying denom trace")
+			continue
+		}
+
+		s.State.SetDenomTrace(chain.Name, balance.Denom, traceResponse.DenomTrace)
+	}
+
+	return queryInfos
+}
+
+// queryCW20Balances queries the wallet balance of each configured CW20 token,
+// keeping the previously fetched balance of the tokens that failed to be queried.
+func (s *Scheduler) queryCW20Balances(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	rpc *tendermint.RPC,
+	entry *types.WalletBalanceEntry,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+	balances := types.Balances{}
+
+	for _, token := range chain.CW20Tokens {
+		balanceResponse, queryInfo, err := rpc.GetCW20Balance(token.Contract, wallet.Address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err == nil {
+			balances = append(balances, types.Balance{
+				Denom:  token.GetDenom(),
+				Amount: balanceResponse.Data.Balance,
+			})
+			continue
+		}
+
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Str("contract", token.Contract).
+			Msg("Error querying CW20 balance")
+
+		if previous, found := entry.CW20Balances.Find(token.GetDenom()); found {
+			balances = append(balances, previous)
+		}
+	}
+
+	entry.CW20Balances = balances
+
+	return queryInfos
+}
+
+// queryEVMBalances queries the wallet native EVM balance and the balances of each configured
+// ERC-20 token via JSON-RPC, keeping the previously fetched balances of the ones that failed.
+func (s *Scheduler) queryEVMBalances(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	evmClient *evm.Client,
+	entry *types.WalletBalanceEntry,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+
+	if evmClient == nil {
+		return queryInfos
+	}
+
+	address := wallet.Address
+	if !bech32.IsHexAddress(address) {
+		hexAddress, err := bech32.Bech32ToHex(address)
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error converting bech32 address to hex")
+			return queryInfos
+		}
+
+		address = hexAddress
+	}
+
+	if chain.EVMNativeDenom != "" {
+		balance, queryInfo, err := evmClient.GetBalance(address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error querying EVM balance")
+		} else {
+			entry.EVMBalances = types.Balances{{Denom: chain.EVMNativeDenom, Amount: balance}}
+		}
+	}
+
+	balances := types.Balances{}
+
+	for _, token := range chain.ERC20Tokens {
+		balance, queryInfo, err := evmClient.GetERC20Balance(token.Contract, address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err == nil {
+			balances = append(balances, types.Balance{Denom: token.GetDenom(), Amount: balance})
+			continue
+		}
+
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Str("contract", token.Contract).
+			Msg("Error querying ERC-20 balance")
+
+		if previous, found := entry.ERC20Balances.Find(token.GetDenom()); found {
+			balances = append(balances, previous)
+		}
+	}
+
+	entry.ERC20Balances = balances
+
+	return queryInfos
+}
+
+func (s *Scheduler) queryStaking(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	rpc *tendermint.RPC,
+	entry *types.WalletBalanceEntry,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+
+	if chain.IsDelegationsQueryEnabled(wallet) {
+		delegationsResponse, queryInfo, err := rpc.GetDelegations(wallet.Address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error querying delegations")
+		} else {
+			delegations := types.Balances{}
+			for _, delegation := range delegationsResponse.DelegationResponses {
+				delegations = delegations.Add(delegation.Balance)
+			}
+
+			entry.Delegations = delegations
+		}
+	}
+
+	if !chain.IsUnbondingDelegationsQueryEnabled(wallet) && !chain.IsRedelegationsQueryEnabled(wallet) {
+		return queryInfos
+	}
+
+	// unbonding delegations and redelegations responses do not have denom,
+	// so we need to fetch the bond denom first
+	bondDenom, bondDenomQueryInfo, err := rpc.GetBondDenom(ctx)
+	if bondDenomQueryInfo != nil {
+		queryInfos = append(queryInfos, *bondDenomQueryInfo)
+	}
+
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Msg("Error querying staking params")
+		return queryInfos
+	}
+
+	if chain.IsUnbondingDelegationsQueryEnabled(wallet) {
+		unbondingsResponse, queryInfo, err := rpc.GetUnbondingDelegations(wallet.Address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error querying unbonding delegations")
+		} else {
+			unbondings := types.Balances{}
+			for _, unbonding := range unbondingsResponse.UnbondingResponses {
+				for _, unbondingEntry := range unbonding.Entries {
+					unbondings = unbondings.Add(types.Balance{Denom: bondDenom, Amount: unbondingEntry.Balance})
+				}
+			}
+
+			entry.Unbondings = unbondings
+		}
+	}
+
+	if chain.IsRedelegationsQueryEnabled(wallet) {
+		redelegationsResponse, queryInfo, err := rpc.GetRedelegations(wallet.Address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error querying redelegations")
+		} else {
+			redelegations := types.Balances{}
+			for _, redelegation := range redelegationsResponse.RedelegationResponses {
+				for _, redelegationEntry := range redelegation.Entries {
+					redelegations = redelegations.Add(types.Balance{Denom: bondDenom, Amount: redelegationEntry.Balance})
+				}
+			}
+
+			entry.Redelegations = redelegations
+		}
+	}
+
+	return queryInfos
+}
+
+func (s *Scheduler) queryDistribution(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	rpc *tendermint.RPC,
+	entry *types.WalletBalanceEntry,
+) []types.QueryInfo {
+	queryInfos := []types.QueryInfo{}
+
+	if chain.IsRewardsQueryEnabled(wallet) {
+		rewardsResponse, queryInfo, err := rpc.GetDelegatorRewards(wallet.Address, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Msg("Error querying delegator rewards")
+		} else {
+			entry.Rewards = rewardsResponse.Total
+		}
+	}
+
+	if wallet.IsValidator() {
+		commissionResponse, queryInfo, err := rpc.GetValidatorCommission(wallet.ValidatorAddress, ctx)
+		queryInfos = append(queryInfos, queryInfo)
+
+		if err != nil {
+			s.Logger.Error().
+				Err(err).
+				Str("chain", chain.Name).
+				Str("wallet", wallet.Address).
+				Str("validator", wallet.ValidatorAddress).
+				Msg("Error querying validator commission")
+		} else {
+			entry.Commission = commissionResponse.Commission.Commission
+		}
+	}
+
+	return queryInfos
+}
+
+func (s *Scheduler) queryVesting(
+	ctx context.Context,
+	chain config.Chain,
+	wallet config.Wallet,
+	rpc *tendermint.RPC,
+	entry *types.WalletBalanceEntry,
+) []types.QueryInfo {
+	if !chain.IsVestingQueryEnabled(wallet) {
+		return []types.QueryInfo{}
+	}
+
+	accountResponse, accountQueryInfo, err := rpc.GetAccount(wallet.Address, ctx)
+	queryInfos := []types.QueryInfo{accountQueryInfo}
+
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Msg("Error querying account")
+	} else {
+		entry.Account = &accountResponse.Account
+	}
+
+	spendableResponse, spendableQueryInfo, err := rpc.GetSpendableBalances(wallet.Address, ctx)
+	queryInfos = append(queryInfos, spendableQueryInfo)
+
+	if err != nil {
+		s.Logger.Error().
+			Err(err).
+			Str("chain", chain.Name).
+			Str("wallet", wallet.Address).
+			Msg("Error querying spendable balances")
+	} else {
+		entry.Spendable = spendableResponse.Balances
+	}
+
+	return queryInfos
+}
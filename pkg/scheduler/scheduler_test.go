@@ -0,0 +1,1185 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"main/assets"
+	configPkg "main/pkg/config"
+	"main/pkg/constants"
+	loggerPkg "main/pkg/logger"
+	statePkg "main/pkg/state"
+	"main/pkg/tendermint"
+	"main/pkg/tracing"
+	"main/pkg/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/guregu/null/v5"
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets:     []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	assert.True(t, found)
+	assert.False(t, entry.Success)
+	assert.Empty(t, entry.Balances)
+	assert.True(t, entry.UpdatedAt.IsZero())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainKeepsLastGoodResult(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets:     []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 1)
+	assert.True(t, queryInfos[0].Success)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	require.Len(t, entry.Balances, 2)
+	updatedAt := entry.UpdatedAt
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos = state.GetQueryInfos()
+	require.Len(t, queryInfos, 1)
+	assert.False(t, queryInfos[0].Success)
+
+	entry, found = state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, updatedAt, entry.UpdatedAt)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerStartAndStop(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:          "chain",
+		LCDEndpoint:   "https://example.com",
+		QueryInterval: time.Hour,
+		Wallets:       []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	require.Eventually(t, func() bool {
+		_, found := state.GetWalletEntry("chain", "address")
+		return found
+	}, time.Second, 10*time.Millisecond)
+
+	scheduler.Stop()
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainStaking(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/staking/v1beta1/delegations/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("delegations.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/staking/v1beta1/params",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("staking-params.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/staking/v1beta1/delegators/address/unbonding_delegations",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("unbonding-delegations.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/staking/v1beta1/delegators/address/redelegations",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("redelegations.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:                      "chain",
+		LCDEndpoint:               "https://example.com",
+		QueryDelegations:          null.BoolFrom(true),
+		QueryUnbondingDelegations: null.BoolFrom(true),
+		QueryRedelegations:        null.BoolFrom(true),
+		Wallets:                   []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 5)
+
+	for _, queryInfo := range queryInfos {
+		assert.True(t, queryInfo.Success)
+	}
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+
+	require.Len(t, entry.Delegations, 1)
+	assert.Equal(t, "uatom", entry.Delegations[0].Denom)
+	assert.Equal(t, int64(3000000), entry.Delegations[0].Amount.TruncateInt64())
+
+	require.Len(t, entry.Unbondings, 1)
+	assert.Equal(t, "uatom", entry.Unbondings[0].Denom)
+	assert.Equal(t, int64(750000), entry.Unbondings[0].Amount.TruncateInt64())
+
+	require.Len(t, entry.Redelegations, 1)
+	assert.Equal(t, "uatom", entry.Redelegations[0].Denom)
+	assert.Equal(t, int64(300000), entry.Redelegations[0].Amount.TruncateInt64())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainStakingParamsFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/staking/v1beta1/params",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:                      "chain",
+		LCDEndpoint:               "https://example.com",
+		QueryUnbondingDelegations: null.BoolFrom(true),
+		Wallets:                   []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.Len(t, entry.Balances, 2)
+	assert.Empty(t, entry.Unbondings)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDistribution(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/distribution/v1beta1/delegators/address/rewards",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("rewards.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/distribution/v1beta1/validators/valoper/commission",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("commission.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoint:  "https://example.com",
+		QueryRewards: null.BoolFrom(true),
+		Wallets:      []configPkg.Wallet{{Address: "address", ValidatorAddress: "valoper"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 3)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+
+	require.Len(t, entry.Rewards, 1)
+	assert.Equal(t, "uatom", entry.Rewards[0].Denom)
+	assert.Equal(t, "2000.123000000000000000", entry.Rewards[0].Amount.String())
+
+	require.Len(t, entry.Commission, 1)
+	assert.Equal(t, "uatom", entry.Commission[0].Denom)
+	assert.Equal(t, "123456789.500000000000000000", entry.Commission[0].Amount.String())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDistributionFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/distribution/v1beta1/delegators/address/rewards",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/distribution/v1beta1/validators/valoper/commission",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoint:  "https://example.com",
+		QueryRewards: null.BoolFrom(true),
+		Wallets:      []configPkg.Wallet{{Address: "address", ValidatorAddress: "valoper"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 3)
+	assert.False(t, queryInfos[1].Success)
+	assert.False(t, queryInfos[2].Success)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.Empty(t, entry.Rewards)
+	assert.Empty(t, entry.Commission)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainVesting(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/auth/v1beta1/accounts/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("account-continuous-vesting.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/spendable_balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("spendable.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoint:  "https://example.com",
+		QueryVesting: null.BoolFrom(true),
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 3)
+
+	for _, queryInfo := range queryInfos {
+		assert.True(t, queryInfo.Success)
+	}
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+
+	require.NotNil(t, entry.Account)
+	assert.True(t, entry.Account.IsVesting())
+	assert.Equal(t, types.ContinuousVestingAccountType, entry.Account.Type)
+	assert.Equal(t, int64(1000000000), entry.Account.StartTime)
+	assert.Equal(t, int64(2000000000), entry.Account.BaseVestingAccount.EndTime)
+	require.Len(t, entry.Account.BaseVestingAccount.DelegatedVesting, 1)
+
+	require.Len(t, entry.Spendable, 1)
+	assert.Equal(t, int64(100000), entry.Spendable[0].Amount.TruncateInt64())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainVestingFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/auth/v1beta1/accounts/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/spendable_balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoint:  "https://example.com",
+		QueryVesting: null.BoolFrom(true),
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.Nil(t, entry.Account)
+	assert.Empty(t, entry.Spendable)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainEndpointFailover(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://first.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://second.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://first.example.com", "https://second.example.com"},
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 1)
+	assert.True(t, queryInfos[0].Success)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, "https://second.example.com", entry.Endpoint)
+
+	endpoints := state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, int64(1), endpoints[0].Failures)
+	assert.Equal(t, int64(1), endpoints[1].Successes)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainEndpointRecovery(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://first.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://second.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://first.example.com", "https://second.example.com"},
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+	rpc := scheduler.RPCs[0]
+
+	for i := 0; i < 2*tendermint.MaxConsecutiveFailures; i++ {
+		scheduler.QueryChain(context.Background(), config.Chains[0], rpc, scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	}
+
+	endpoints := state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.False(t, endpoints[0].Healthy)
+	assert.Equal(t, int64(tendermint.MaxConsecutiveFailures), endpoints[0].Failures)
+
+	// the first endpoint recovers and is queried again once the recheck interval passes
+	httpmock.RegisterResponder(
+		"GET",
+		"https://first.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	rpc.Endpoints[0].LastQueriedAt = time.Now().Add(-tendermint.UnhealthyRecheckInterval)
+
+	for i := 0; i < 2; i++ {
+		scheduler.QueryChain(context.Background(), config.Chains[0], rpc, scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	}
+
+	endpoints = state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.True(t, endpoints[0].Healthy)
+	assert.Equal(t, int64(1), endpoints[0].Successes)
+
+	// and then it's back in rotation
+	for i := 0; i < 2; i++ {
+		scheduler.QueryChain(context.Background(), config.Chains[0], rpc, scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	}
+
+	endpoints = state.GetChainEndpoints("chain")
+	assert.Equal(t, int64(2), endpoints[0].Successes)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainHeightRegression(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://first.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")).
+			HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"100"}}),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://first.example.com", "https://second.example.com"},
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	require.True(t, entry.Success)
+	assert.Equal(t, int64(100), entry.Height)
+	updatedAt := entry.UpdatedAt
+
+	// both endpoints are now lagging, so the query is retried on each of them and fails,
+	// with the previous balance kept
+	httpmock.RegisterResponder(
+		"GET",
+		"https://first.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")).
+			HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"90"}}),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://second.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")).
+			HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"95"}}),
+	)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found = state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.False(t, entry.Success)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, updatedAt, entry.UpdatedAt)
+
+	endpoints := state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, int64(tendermint.HeightRegressionRetries+1), endpoints[0].HeightRegressions)
+	assert.Equal(t, int64(tendermint.HeightRegressionRetries+1), endpoints[1].HeightRegressions)
+
+	// second endpoint catches up, so the balance is returned from it
+	httpmock.RegisterResponder(
+		"GET",
+		"https://second.example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")).
+			HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"101"}}),
+	)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found = state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	assert.Equal(t, "https://second.example.com", entry.Endpoint)
+	assert.Equal(t, int64(101), entry.Height)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainABCITransport(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://rpc.example.com/abci_query?path=%22%2Fcosmos.bank.v1beta1.Query%2FAllBalances%22&data=0x0a0761646472657373",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("abci-query-balances.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:              "chain",
+		BalancesTransport: configPkg.BalancesTransportABCI,
+		RPCEndpoint:       "https://rpc.example.com",
+		Wallets:           []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, "https://rpc.example.com", entry.Endpoint)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainPagination(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address?pagination.limit=1",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-page-1.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address?pagination.key=dXN0YWtl&pagination.limit=1",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-page-2.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		PageSize:    1,
+		Wallets:     []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 1)
+	assert.True(t, queryInfos[0].Success)
+	assert.Equal(t, 2, queryInfos[0].Pages)
+	assert.Equal(t, "https://example.com/cosmos/bank/v1beta1/balances/address?pagination.limit=1", queryInfos[0].URL)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, "uatom", entry.Balances[0].Denom)
+	assert.Equal(t, "ustake", entry.Balances[1].Denom)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainPaginationSameEndpoint(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for _, host := range []string{"https://first.example.com", "https://second.example.com"} {
+		httpmock.RegisterResponder(
+			"GET",
+			host+"/cosmos/bank/v1beta1/balances/address?pagination.limit=1",
+			httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-page-1.json")).
+				HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"100"}}),
+		)
+		httpmock.RegisterResponder(
+			"GET",
+			host+"/cosmos/bank/v1beta1/balances/address?pagination.key=dXN0YWtl&pagination.limit=1",
+			httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-page-2.json")).
+				HeaderSet(http.Header{constants.HeaderBlockHeight: []string{"100"}}),
+		)
+	}
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:         "chain",
+		LCDEndpoints: []string{"https://first.example.com", "https://second.example.com"},
+		PageSize:     1,
+		Wallets:      []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	require.Len(t, entry.Balances, 2)
+	assert.Equal(t, "https://first.example.com", entry.Endpoint)
+
+	endpoints := state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, int64(2), endpoints[0].Successes)
+	assert.Zero(t, endpoints[1].Successes)
+
+	// the next poll goes to the other endpoint, with all pages fetched from it
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	entry, found = state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	assert.Equal(t, "https://second.example.com", entry.Endpoint)
+
+	endpoints = state.GetChainEndpoints("chain")
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, int64(2), endpoints[0].Successes)
+	assert.Equal(t, int64(2), endpoints[1].Successes)
+	assert.Zero(t, endpoints[0].HeightRegressions)
+	assert.Zero(t, endpoints[1].HeightRegressions)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDenomTraces(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-ibc.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/ibc/apps/transfer/v1/denom_traces/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("denom-trace.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:             "chain",
+		LCDEndpoint:      "https://example.com",
+		ResolveIBCDenoms: null.BoolFrom(true),
+		Wallets:          []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+
+	trace, found := state.GetDenomTrace("chain", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2")
+	require.True(t, found)
+	assert.Equal(t, "uosmo", trace.BaseDenom)
+	assert.Equal(t, "transfer/channel-141", trace.Path)
+
+	// denom traces are cached, so are not queried again
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	require.Len(t, state.GetQueryInfos(), 1)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDenomTracesFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance-ibc.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/ibc/apps/transfer/v1/denom_traces/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:             "chain",
+		LCDEndpoint:      "https://example.com",
+		ResolveIBCDenoms: null.BoolFrom(true),
+		Wallets:          []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.False(t, queryInfos[1].Success)
+
+	_, found := state.GetDenomTrace("chain", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2")
+	assert.False(t, found)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	assert.Len(t, entry.Balances, 2)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDenomsMetadata(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/denoms_metadata",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("denoms-metadata.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:                          "chain",
+		LCDEndpoint:                   "https://example.com",
+		DiscoverDenomsMetadata:        null.BoolFrom(true),
+		DenomsMetadataRefreshInterval: time.Hour,
+		Wallets:                       []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+
+	metadata, found := state.GetDenomMetadata("chain", "aevmos")
+	require.True(t, found)
+	assert.Equal(t, "evmos", metadata.Display)
+
+	exponent, found := metadata.GetDisplayExponent()
+	require.True(t, found)
+	assert.Equal(t, 18, exponent)
+
+	// not refreshed until the refresh interval passes
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	require.Len(t, state.GetQueryInfos(), 1)
+	assert.Equal(t, 3, httpmock.GetTotalCallCount())
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainDenomsMetadataFail(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/denoms_metadata",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:                          "chain",
+		LCDEndpoint:                   "https://example.com",
+		DiscoverDenomsMetadata:        null.BoolFrom(true),
+		DenomsMetadataRefreshInterval: time.Hour,
+		Wallets:                       []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+	assert.False(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+	assert.True(t, state.GetDenomsMetadataUpdatedAt("chain").IsZero())
+
+	// retried on the next poll
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	require.Len(t, state.GetQueryInfos(), 2)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainCW20Balances(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmwasm/wasm/v1/contract/contract1/smart/eyJiYWxhbmNlIjp7ImFkZHJlc3MiOiJhZGRyZXNzIn19",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("cw20-balance.json")),
+	)
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmwasm/wasm/v1/contract/contract2/smart/eyJiYWxhbmNlIjp7ImFkZHJlc3MiOiJhZGRyZXNzIn19",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		CW20Tokens:  []configPkg.CW20Token{{Contract: "contract1"}, {Contract: "contract2"}},
+		Wallets:     []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:        "chain",
+		Wallet:       config.Chains[0].Wallets[0],
+		CW20Balances: types.Balances{{Denom: "cw20:contract2", Amount: math.LegacyNewDec(100)}},
+	})
+
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 3)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+	assert.False(t, queryInfos[2].Success)
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	require.True(t, found)
+	require.Len(t, entry.CW20Balances, 2)
+	assert.Equal(t, "cw20:contract1", entry.CW20Balances[0].Denom)
+	assert.Equal(t, math.LegacyNewDec(1500000), entry.CW20Balances[0].Amount)
+	// kept from the previous query, as the contract query failed
+	assert.Equal(t, "cw20:contract2", entry.CW20Balances[1].Denom)
+	assert.Equal(t, math.LegacyNewDec(100), entry.CW20Balances[1].Amount)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainEVMBalances(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://evm.example.com",
+		httpmock.BodyContainsString(`"method":"eth_getBalance"`),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-balance.json")),
+	)
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://evm.example.com",
+		httpmock.BodyContainsString(`"to":"0xtoken1"`),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-erc20-balance.json")),
+	)
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://evm.example.com",
+		httpmock.BodyContainsString(`"to":"0xtoken2"`),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-error.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:           "chain",
+		LCDEndpoint:    "https://example.com",
+		Bech32Prefix:   "evmos",
+		EVMRPCEndpoint: "https://evm.example.com",
+		EVMNativeDenom: "aevmos",
+		ERC20Tokens:    []configPkg.ERC20Token{{Contract: "0xtoken1"}, {Contract: "0xtoken2"}},
+		Wallets:        []configPkg.Wallet{{Address: "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+	require.NotNil(t, scheduler.EVMClients[0])
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 4)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+	assert.True(t, queryInfos[2].Success)
+	assert.False(t, queryInfos[3].Success)
+
+	entry, found := state.GetWalletEntry("chain", "0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b")
+	require.True(t, found)
+	assert.True(t, entry.Success)
+	assert.NotEmpty(t, entry.Balances)
+	assert.Equal(t, types.Balances{{Denom: "aevmos", Amount: math.LegacyMustNewDecFromStr("1000000000000000000")}}, entry.EVMBalances)
+	assert.Equal(t, types.Balances{{Denom: "erc20:0xtoken1", Amount: math.LegacyNewDec(2500000)}}, entry.ERC20Balances)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainEVMBalancesBech32Address(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+	httpmock.RegisterMatcherResponder(
+		"POST",
+		"https://evm.example.com",
+		httpmock.BodyContainsString(`"params":["0x3a29e4ff6d3d2e7e8c7b2e5b6a0a2a8c2e6f1a0b","latest"]`),
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("evm-balance.json")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:           "chain",
+		LCDEndpoint:    "https://example.com",
+		EVMRPCEndpoint: "https://evm.example.com",
+		EVMNativeDenom: "aevmos",
+		Wallets:        []configPkg.Wallet{{Address: "evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k"}},
+	}}}
+
+	state := statePkg.NewState()
+	tracer := tracing.InitNoopTracer()
+	logger := loggerPkg.GetNopLogger()
+	scheduler := NewScheduler(config, state, *logger, tracer)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	queryInfos := state.GetQueryInfos()
+	require.Len(t, queryInfos, 2)
+	assert.True(t, queryInfos[0].Success)
+	assert.True(t, queryInfos[1].Success)
+
+	entry, found := state.GetWalletEntry("chain", "evmos18g57flmd85h8arrm9edk5z323shx7xstlm5z5k")
+	require.True(t, found)
+	require.Len(t, entry.EVMBalances, 1)
+}
+
+type testListener struct {
+	chains []string
+}
+
+func (l *testListener) OnChainQueried(ctx context.Context, chain configPkg.Chain) {
+	l.chains = append(l.chains, chain.Name)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainNotifiesListeners(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	config := &configPkg.Config{Chains: []configPkg.Chain{{
+		Name:        "chain",
+		LCDEndpoint: "https://example.com",
+		Wallets:     []configPkg.Wallet{{Address: "address"}},
+	}}}
+
+	state := statePkg.NewState()
+	scheduler := NewScheduler(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	listener := &testListener{}
+	scheduler.AddListener(listener)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	assert.Equal(t, []string{"chain"}, listener.chains)
+}
+
+//nolint:paralleltest // disabled due to httpmock usage
+func TestSchedulerQueryChainBalanceSamples(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewBytesResponder(200, assets.GetBytesOrPanic("balance.json")),
+	)
+
+	config := &configPkg.Config{
+		BurnRateConfig: configPkg.BurnRateConfig{Enabled: true, Window: time.Hour},
+		Chains: []configPkg.Chain{{
+			Name:        "chain",
+			LCDEndpoint: "https://example.com",
+			Wallets:     []configPkg.Wallet{{Address: "address"}},
+		}},
+	}
+
+	state := statePkg.NewState()
+	scheduler := NewScheduler(config, state, *loggerPkg.GetNopLogger(), tracing.InitNoopTracer())
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+
+	samples := state.GetBalanceSamples("chain", "address")
+	require.Len(t, samples, 2)
+	require.Len(t, samples[1].Amounts, 2)
+
+	// failed queries keep the previous balances, which are not sampled again
+	httpmock.RegisterResponder(
+		"GET",
+		"https://example.com/cosmos/bank/v1beta1/balances/address",
+		httpmock.NewErrorResponder(errors.New("custom error")),
+	)
+
+	scheduler.QueryChain(context.Background(), config.Chains[0], scheduler.RPCs[0], scheduler.BalanceFetchers[0], scheduler.EVMClients[0])
+	assert.Len(t, state.GetBalanceSamples("chain", "address"), 2)
+}
@@ -0,0 +1,184 @@
+package state
+
+import (
+	"main/pkg/types"
+	"sync"
+	"time"
+)
+
+type State struct {
+	Entries     map[string]map[string]types.WalletBalanceEntry
+	QueryInfos  map[string][]types.QueryInfo
+	Endpoints   map[string][]types.EndpointStatus
+	DenomTraces map[string]map[string]types.DenomTrace
+
+	// BalanceSamples are the wallets balances fetched within the burn rate window, oldest first.
+	BalanceSamples map[string]map[string][]types.BalanceSample
+
+	DenomsMetadata          map[string]map[string]types.DenomMetadata
+	DenomsMetadataUpdatedAt map[string]time.Time
+
+	Mutex sync.RWMutex
+}
+
+func NewState() *State {
+	return &State{
+		Entries:     make(map[string]map[string]types.WalletBalanceEntry),
+		QueryInfos:  make(map[string][]types.QueryInfo),
+		Endpoints:   make(map[string][]types.EndpointStatus),
+		DenomTraces: make(map[string]map[string]types.DenomTrace),
+
+		BalanceSamples: make(map[string]map[string][]types.BalanceSample),
+
+		DenomsMetadata:          make(map[string]map[string]types.DenomMetadata),
+		DenomsMetadataUpdatedAt: make(map[string]time.Time),
+	}
+}
+
+func (s *State) SetWalletEntry(entry types.WalletBalanceEntry) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if _, ok := s.Entries[entry.Chain]; !ok {
+		s.Entries[entry.Chain] = make(map[string]types.WalletBalanceEntry)
+	}
+
+	s.Entries[entry.Chain][entry.Wallet.Address] = entry
+}
+
+func (s *State) GetWalletEntry(chain string, address string) (types.WalletBalanceEntry, bool) {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	chainEntries, ok := s.Entries[chain]
+	if !ok {
+		return types.WalletBalanceEntry{}, false
+	}
+
+	entry, ok := chainEntries[address]
+	return entry, ok
+}
+
+// AddBalanceSample adds the wallet balance sample, dropping the ones
+// fetched earlier than the window before it.
+func (s *State) AddBalanceSample(chain string, address string, sample types.BalanceSample, window time.Duration) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if _, ok := s.BalanceSamples[chain]; !ok {
+		s.BalanceSamples[chain] = make(map[string][]types.BalanceSample)
+	}
+
+	samples := append(s.BalanceSamples[chain][address], sample)
+	windowStart := sample.UpdatedAt.Add(-window)
+
+	firstIndex := 0
+	for firstIndex < len(samples) && samples[firstIndex].UpdatedAt.Before(windowStart) {
+		firstIndex++
+	}
+
+	s.BalanceSamples[chain][address] = samples[firstIndex:]
+}
+
+func (s *State) GetBalanceSamples(chain string, address string) []types.BalanceSample {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	chainSamples, ok := s.BalanceSamples[chain]
+	if !ok {
+		return nil
+	}
+
+	return chainSamples[address]
+}
+
+func (s *State) SetChainQueryInfos(chain string, queryInfos []types.QueryInfo) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.QueryInfos[chain] = queryInfos
+}
+
+func (s *State) GetQueryInfos() []types.QueryInfo {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	queryInfos := []types.QueryInfo{}
+	for _, chainQueryInfos := range s.QueryInfos {
+		queryInfos = append(queryInfos, chainQueryInfos...)
+	}
+
+	return queryInfos
+}
+
+func (s *State) SetChainEndpoints(chain string, endpoints []types.EndpointStatus) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	s.Endpoints[chain] = endpoints
+}
+
+func (s *State) GetChainEndpoints(chain string) []types.EndpointStatus {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	return s.Endpoints[chain]
+}
+
+func (s *State) SetDenomTrace(chain string, denom string, trace types.DenomTrace) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	if _, ok := s.DenomTraces[chain]; !ok {
+		s.DenomTraces[chain] = make(map[string]types.DenomTrace)
+	}
+
+	s.DenomTraces[chain][denom] = trace
+}
+
+func (s *State) GetDenomTrace(chain string, denom string) (types.DenomTrace, bool) {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	chainTraces, ok := s.DenomTraces[chain]
+	if !ok {
+		return types.DenomTrace{}, false
+	}
+
+	trace, ok := chainTraces[denom]
+	return trace, ok
+}
+
+// SetChainDenomsMetadata replaces all the chain denoms metadata with the newly fetched one.
+func (s *State) SetChainDenomsMetadata(chain string, metadatas []types.DenomMetadata) {
+	s.Mutex.Lock()
+	defer s.Mutex.Unlock()
+
+	chainMetadata := make(map[string]types.DenomMetadata, len(metadatas))
+	for _, metadata := range metadatas {
+		chainMetadata[metadata.Base] = metadata
+	}
+
+	s.DenomsMetadata[chain] = chainMetadata
+	s.DenomsMetadataUpdatedAt[chain] = time.Now()
+}
+
+func (s *State) GetDenomMetadata(chain string, denom string) (types.DenomMetadata, bool) {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	chainMetadata, ok := s.DenomsMetadata[chain]
+	if !ok {
+		return types.DenomMetadata{}, false
+	}
+
+	metadata, ok := chainMetadata[denom]
+	return metadata, ok
+}
+
+func (s *State) GetDenomsMetadataUpdatedAt(chain string) time.Time {
+	s.Mutex.RLock()
+	defer s.Mutex.RUnlock()
+
+	return s.DenomsMetadataUpdatedAt[chain]
+}
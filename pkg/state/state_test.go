@@ -0,0 +1,121 @@
+package state
+
+import (
+	"main/pkg/config"
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateWalletEntries(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+
+	_, found := state.GetWalletEntry("chain", "address")
+	assert.False(t, found)
+
+	state.SetWalletEntry(types.WalletBalanceEntry{
+		Chain:  "chain",
+		Wallet: config.Wallet{Address: "address"},
+	})
+
+	entry, found := state.GetWalletEntry("chain", "address")
+	assert.True(t, found)
+	assert.Equal(t, "address", entry.Wallet.Address)
+
+	_, found = state.GetWalletEntry("chain", "another")
+	assert.False(t, found)
+}
+
+func TestStateQueryInfos(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	require.Empty(t, state.GetQueryInfos())
+
+	state.SetChainQueryInfos("chain", []types.QueryInfo{{Chain: "chain", URL: "url1"}})
+	state.SetChainQueryInfos("chain2", []types.QueryInfo{{Chain: "chain2", URL: "url2"}})
+	require.Len(t, state.GetQueryInfos(), 2)
+
+	state.SetChainQueryInfos("chain", []types.QueryInfo{})
+	require.Len(t, state.GetQueryInfos(), 1)
+}
+
+func TestStateEndpoints(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	require.Empty(t, state.GetChainEndpoints("chain"))
+
+	state.SetChainEndpoints("chain", []types.EndpointStatus{{URL: "https://example.com", Healthy: true}})
+	require.Len(t, state.GetChainEndpoints("chain"), 1)
+	require.Empty(t, state.GetChainEndpoints("chain2"))
+}
+
+func TestStateDenomTraces(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+
+	_, found := state.GetDenomTrace("chain", "ibc/HASH")
+	assert.False(t, found)
+
+	state.SetDenomTrace("chain", "ibc/HASH", types.DenomTrace{Path: "transfer/channel-0", BaseDenom: "uosmo"})
+
+	trace, found := state.GetDenomTrace("chain", "ibc/HASH")
+	assert.True(t, found)
+	assert.Equal(t, "uosmo", trace.BaseDenom)
+
+	_, found = state.GetDenomTrace("chain", "ibc/ANOTHER")
+	assert.False(t, found)
+}
+
+func TestStateDenomsMetadata(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	assert.True(t, state.GetDenomsMetadataUpdatedAt("chain").IsZero())
+
+	_, found := state.GetDenomMetadata("chain", "uatom")
+	assert.False(t, found)
+
+	state.SetChainDenomsMetadata("chain", []types.DenomMetadata{{Base: "uatom", Display: "atom"}})
+	assert.False(t, state.GetDenomsMetadataUpdatedAt("chain").IsZero())
+
+	metadata, found := state.GetDenomMetadata("chain", "uatom")
+	assert.True(t, found)
+	assert.Equal(t, "atom", metadata.Display)
+
+	state.SetChainDenomsMetadata("chain", []types.DenomMetadata{{Base: "ustake", Display: "stake"}})
+
+	_, found = state.GetDenomMetadata("chain", "uatom")
+	assert.False(t, found)
+}
+
+func TestStateBalanceSamples(t *testing.T) {
+	t.Parallel()
+
+	state := NewState()
+	assert.Empty(t, state.GetBalanceSamples("chain", "address"))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for index := 0; index < 5; index++ {
+		state.AddBalanceSample("chain", "address", types.BalanceSample{
+			UpdatedAt: start.Add(time.Duration(index) * time.Hour),
+		}, 2*time.Hour)
+	}
+
+	// only the samples within 2 hours before the latest one are kept
+	samples := state.GetBalanceSamples("chain", "address")
+	require.Len(t, samples, 3)
+	assert.Equal(t, start.Add(2*time.Hour), samples[0].UpdatedAt)
+	assert.Equal(t, start.Add(4*time.Hour), samples[2].UpdatedAt)
+
+	assert.Empty(t, state.GetBalanceSamples("chain", "another"))
+	assert.Empty(t, state.GetBalanceSamples("another", "address"))
+}
@@ -0,0 +1,167 @@
+package tendermint
+
+import (
+	"main/pkg/types"
+	"time"
+)
+
+// MaxConsecutiveFailures is the amount of failed queries in a row
+// after which the endpoint is considered unhealthy.
+const MaxConsecutiveFailures = 3
+
+// UnhealthyRecheckInterval is how often an unhealthy endpoint is queried as if it was healthy,
+// so it's known when it recovers, as otherwise it's only queried if all other endpoints fail.
+const UnhealthyRecheckInterval = 5 * time.Minute
+
+// HeightRegressionRetries is the amount of times the query is retried on the same
+// endpoint if it returned an older block than the one returned previously.
+const HeightRegressionRetries = 1
+
+type Endpoint struct {
+	URL                 string
+	Successes           int64
+	Failures            int64
+	ConsecutiveFailures int64
+	Latency             time.Duration
+	Height              int64
+	HeightRegressions   int64
+	LastQueriedAt       time.Time
+}
+
+func NewEndpoints(urls []string) []*Endpoint {
+	endpoints := make([]*Endpoint, len(urls))
+	for index, url := range urls {
+		endpoints[index] = &Endpoint{URL: url}
+	}
+
+	return endpoints
+}
+
+// getEndpointsToQuery returns the endpoints in the order they should be queried:
+// the healthy ones starting from the current one, which are followed by the unhealthy ones
+// (failing or lagging behind the others on block height) as the last resort.
+// An unhealthy endpoint that wasn't queried for UnhealthyRecheckInterval is queried
+// as a healthy one, so it gets its failures reset and height updated once it recovers.
+// Should be called with the RPC mutex held.
+func (rpc *RPC) getEndpointsToQuery() []*Endpoint {
+	if len(rpc.Endpoints) == 0 {
+		return []*Endpoint{}
+	}
+
+	maxHeight := rpc.getMaxHeight()
+
+	healthy := make([]*Endpoint, 0, len(rpc.Endpoints))
+	unhealthy := make([]*Endpoint, 0, len(rpc.Endpoints))
+
+	for index := range rpc.Endpoints {
+		endpoint := rpc.Endpoints[(rpc.NextEndpoint+index)%len(rpc.Endpoints)]
+		if rpc.isHealthy(endpoint, maxHeight) || time.Since(endpoint.LastQueriedAt) >= UnhealthyRecheckInterval {
+			healthy = append(healthy, endpoint)
+		} else {
+			unhealthy = append(unhealthy, endpoint)
+		}
+	}
+
+	return append(healthy, unhealthy...)
+}
+
+// RotateEndpoints makes the next endpoint the current one. It's called once per poll,
+// so all queries of a poll (including all pages of a list query) go to the same endpoint
+// and won't get spurious height regressions because of the endpoints not being in sync.
+func (rpc *RPC) RotateEndpoints() {
+	rpc.Mutex.Lock()
+	defer rpc.Mutex.Unlock()
+
+	if len(rpc.Endpoints) == 0 {
+		return
+	}
+
+	rpc.NextEndpoint = (rpc.NextEndpoint + 1) % len(rpc.Endpoints)
+}
+
+// stickToEndpoint makes the endpoint the current one, so the following queries
+// go to the one that succeeded instead of the one that has failed.
+func (rpc *RPC) stickToEndpoint(endpoint *Endpoint) {
+	rpc.Mutex.Lock()
+	defer rpc.Mutex.Unlock()
+
+	for index, candidate := range rpc.Endpoints {
+		if candidate == endpoint {
+			rpc.NextEndpoint = index
+			return
+		}
+	}
+}
+
+func (rpc *RPC) getMaxHeight() int64 {
+	var maxHeight int64
+	for _, endpoint := range rpc.Endpoints {
+		if endpoint.Height > maxHeight {
+			maxHeight = endpoint.Height
+		}
+	}
+
+	return maxHeight
+}
+
+func (rpc *RPC) isHealthy(endpoint *Endpoint, maxHeight int64) bool {
+	if endpoint.ConsecutiveFailures >= MaxConsecutiveFailures {
+		return false
+	}
+
+	// height is unknown if the endpoint does not return it in a header
+	if endpoint.Height == 0 {
+		return true
+	}
+
+	return maxHeight-endpoint.Height <= rpc.MaxBlockLag
+}
+
+func (rpc *RPC) reportQuery(endpoint *Endpoint, queryInfo types.QueryInfo, height int64) {
+	rpc.Mutex.Lock()
+	defer rpc.Mutex.Unlock()
+
+	endpoint.Latency = queryInfo.Duration
+	endpoint.LastQueriedAt = time.Now()
+
+	if height > 0 {
+		endpoint.Height = height
+	}
+
+	if queryInfo.Success {
+		endpoint.Successes++
+		endpoint.ConsecutiveFailures = 0
+	} else {
+		endpoint.Failures++
+		endpoint.ConsecutiveFailures++
+	}
+}
+
+func (rpc *RPC) reportHeightRegression(endpoint *Endpoint) {
+	rpc.Mutex.Lock()
+	defer rpc.Mutex.Unlock()
+
+	endpoint.HeightRegressions++
+}
+
+func (rpc *RPC) GetEndpointsStatus() []types.EndpointStatus {
+	rpc.Mutex.Lock()
+	defer rpc.Mutex.Unlock()
+
+	maxHeight := rpc.getMaxHeight()
+
+	statuses := make([]types.EndpointStatus, len(rpc.Endpoints))
+	for index, endpoint := range rpc.Endpoints {
+		statuses[index] = types.EndpointStatus{
+			URL:               endpoint.URL,
+			Successes:         endpoint.Successes,
+			Failures:          endpoint.Failures,
+			Latency:           endpoint.Latency,
+			Height:            endpoint.Height,
+			Healthy:           rpc.isHealthy(endpoint, maxHeight),
+			HeightRegressions: endpoint.HeightRegressions,
+		}
+	}
+
+	return statuses
+}
@@ -0,0 +1,109 @@
+package tendermint
+
+import (
+	"main/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getEndpointURLs(endpoints []*Endpoint) []string {
+	urls := make([]string, len(endpoints))
+	for index, endpoint := range endpoints {
+		urls[index] = endpoint.URL
+	}
+
+	return urls
+}
+
+func TestEndpointsRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	rpc := &RPC{Endpoints: NewEndpoints([]string{"first", "second", "third"})}
+
+	assert.Equal(t, []string{"first", "second", "third"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+	assert.Equal(t, []string{"first", "second", "third"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	rpc.RotateEndpoints()
+	assert.Equal(t, []string{"second", "third", "first"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	rpc.RotateEndpoints()
+	assert.Equal(t, []string{"third", "first", "second"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	rpc.RotateEndpoints()
+	assert.Equal(t, []string{"first", "second", "third"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+}
+
+func TestEndpointsStickToEndpoint(t *testing.T) {
+	t.Parallel()
+
+	rpc := &RPC{Endpoints: NewEndpoints([]string{"first", "second", "third"})}
+
+	rpc.stickToEndpoint(rpc.Endpoints[2])
+	assert.Equal(t, []string{"third", "first", "second"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	rpc.RotateEndpoints()
+	assert.Equal(t, []string{"first", "second", "third"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+}
+
+func TestEndpointsRotateNoEndpoints(t *testing.T) {
+	t.Parallel()
+
+	rpc := &RPC{}
+
+	rpc.RotateEndpoints()
+	assert.Empty(t, rpc.getEndpointsToQuery())
+}
+
+func TestEndpointsUnhealthyLast(t *testing.T) {
+	t.Parallel()
+
+	rpc := &RPC{Endpoints: NewEndpoints([]string{"first", "second", "third"}), MaxBlockLag: 10}
+
+	for i := 0; i < MaxConsecutiveFailures; i++ {
+		rpc.reportQuery(rpc.Endpoints[0], types.QueryInfo{Success: false}, 0)
+	}
+
+	rpc.reportQuery(rpc.Endpoints[1], types.QueryInfo{Success: true}, 100)
+	rpc.reportQuery(rpc.Endpoints[2], types.QueryInfo{Success: true}, 89)
+
+	assert.Equal(t, []string{"second", "first", "third"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	statuses := rpc.GetEndpointsStatus()
+	require.Len(t, statuses, 3)
+	assert.False(t, statuses[0].Healthy)
+	assert.Equal(t, int64(MaxConsecutiveFailures), statuses[0].Failures)
+	assert.True(t, statuses[1].Healthy)
+	assert.Equal(t, int64(100), statuses[1].Height)
+	assert.False(t, statuses[2].Healthy)
+
+	// a successful query and catching up on height make endpoints healthy again
+	rpc.reportQuery(rpc.Endpoints[0], types.QueryInfo{Success: true}, 0)
+	rpc.reportQuery(rpc.Endpoints[2], types.QueryInfo{Success: true}, 95)
+
+	statuses = rpc.GetEndpointsStatus()
+	assert.True(t, statuses[0].Healthy)
+	assert.True(t, statuses[2].Healthy)
+}
+
+func TestEndpointsUnhealthyRecheck(t *testing.T) {
+	t.Parallel()
+
+	rpc := &RPC{Endpoints: NewEndpoints([]string{"first", "second"}), MaxBlockLag: 10}
+
+	for i := 0; i < MaxConsecutiveFailures; i++ {
+		rpc.reportQuery(rpc.Endpoints[0], types.QueryInfo{Success: false}, 0)
+	}
+
+	assert.Equal(t, []string{"second", "first"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	// not queried for a while, so it's tried in its turn to know whether it has recovered
+	rpc.Endpoints[0].LastQueriedAt = time.Now().Add(-UnhealthyRecheckInterval)
+	assert.Equal(t, []string{"first", "second"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+
+	rpc.reportQuery(rpc.Endpoints[0], types.QueryInfo{Success: true}, 100)
+	assert.True(t, rpc.GetEndpointsStatus()[0].Healthy)
+	assert.Equal(t, []string{"first", "second"}, getEndpointURLs(rpc.getEndpointsToQuery()))
+}
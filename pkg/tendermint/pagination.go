@@ -0,0 +1,74 @@
+package tendermint
+
+import (
+	"context"
+	"encoding/base64"
+	"main/pkg/types"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// getAllPages fetches all pages of a list query, calling onPage for each of them.
+// Returns the query info of the last page, with the URL of the first one (so the page keys
+// won't end up in metrics labels) and the duration and pages count of all of them.
+func getAllPages[T types.PaginatedResponse](
+	rpc *RPC,
+	path string,
+	address string,
+	ctx context.Context,
+	onPage func(page T),
+) (types.QueryInfo, error) {
+	var (
+		nextKey       []byte
+		firstURL      string
+		totalDuration time.Duration
+		pages         int
+	)
+
+	for {
+		var page T
+		queryInfo, err := rpc.Get(rpc.getPagePath(path, nextKey), address, &page, ctx)
+
+		if firstURL == "" {
+			firstURL = queryInfo.URL
+		}
+
+		totalDuration += queryInfo.Duration
+		queryInfo.URL = firstURL
+		queryInfo.Duration = totalDuration
+
+		if err != nil {
+			queryInfo.Pages = pages
+			return queryInfo, err
+		}
+
+		pages++
+		queryInfo.Pages = pages
+
+		onPage(page)
+
+		nextKey = page.GetNextKey()
+		if len(nextKey) == 0 {
+			return queryInfo, nil
+		}
+	}
+}
+
+func (rpc *RPC) getPagePath(path string, nextKey []byte) string {
+	params := url.Values{}
+
+	if rpc.PageSize > 0 {
+		params.Set("pagination.limit", strconv.FormatUint(rpc.PageSize, 10))
+	}
+
+	if len(nextKey) > 0 {
+		params.Set("pagination.key", base64.StdEncoding.EncodeToString(nextKey))
+	}
+
+	if len(params) == 0 {
+		return path
+	}
+
+	return path + "?" + params.Encode()
+}
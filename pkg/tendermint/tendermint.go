@@ -2,11 +2,16 @@ package tendermint
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"main/pkg/config"
 	"main/pkg/http"
 	"main/pkg/types"
 	"main/pkg/utils"
+	nethttp "net/http"
+	"strings"
 	"sync"
 
 	"go.opentelemetry.io/otel/trace"
@@ -15,45 +20,349 @@ import (
 )
 
 type RPC struct {
-	Client *http.Client
-	URL    string
-	Logger zerolog.Logger
-	Tracer trace.Tracer
+	Chain       string
+	Client      *http.Client
+	Endpoints   []*Endpoint
+	MaxBlockLag int64
+	PageSize    uint64
+	Logger      zerolog.Logger
+	Tracer      trace.Tracer
 
 	LastQueryHeight map[string]int64
+	BondDenom       string
+	NextEndpoint    int
 	Mutex           sync.Mutex
 }
 
 func NewRPC(chain config.Chain, logger zerolog.Logger, tracer trace.Tracer) *RPC {
 	return &RPC{
+		Chain:           chain.Name,
 		Client:          http.NewClient(logger, chain.Name, tracer),
-		URL:             chain.LCDEndpoint,
-		Logger:          logger.With().Str("component", "rpc").Logger(),
+		Endpoints:       NewEndpoints(chain.GetLCDEndpoints()),
+		MaxBlockLag:     chain.MaxBlockLag,
+		PageSize:        chain.PageSize,
+		Logger:          logger.With().Str("component", "rpc").Str("chain", chain.Name).Logger(),
 		LastQueryHeight: make(map[string]int64),
 		Tracer:          tracer,
 	}
 }
 
 func (rpc *RPC) GetWalletBalances(address string, ctx context.Context) (*types.BalanceResponse, types.QueryInfo, error) {
-	lastHeight, _ := rpc.LastQueryHeight[address]
+	path := fmt.Sprintf(
+		"/cosmos/bank/v1beta1/balances/%s",
+		address,
+	)
+
+	response := &types.BalanceResponse{}
+	queryInfo, err := getAllPages(rpc, path, address, ctx, func(page *types.BalanceResponse) {
+		response.Balances = append(response.Balances, page.Balances...)
+	})
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetSpendableBalances(
+	address string,
+	ctx context.Context,
+) (*types.BalanceResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/bank/v1beta1/spendable_balances/%s",
+		address,
+	)
+
+	response := &types.BalanceResponse{}
+	queryInfo, err := getAllPages(rpc, path, address, ctx, func(page *types.BalanceResponse) {
+		response.Balances = append(response.Balances, page.Balances...)
+	})
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetAccount(address string, ctx context.Context) (*types.AccountResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/auth/v1beta1/accounts/%s",
+		address,
+	)
+
+	var response *types.AccountResponse
+	queryInfo, err := rpc.Get(path, address, &response, ctx)
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetDelegations(address string, ctx context.Context) (*types.DelegationsResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/staking/v1beta1/delegations/%s",
+		address,
+	)
+
+	response := &types.DelegationsResponse{}
+	queryInfo, err := getAllPages(rpc, path, address, ctx, func(page *types.DelegationsResponse) {
+		response.DelegationResponses = append(response.DelegationResponses, page.DelegationResponses...)
+	})
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetUnbondingDelegations(
+	address string,
+	ctx context.Context,
+) (*types.UnbondingDelegationsResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/staking/v1beta1/delegators/%s/unbonding_delegations",
+		address,
+	)
+
+	response := &types.UnbondingDelegationsResponse{}
+	queryInfo, err := getAllPages(rpc, path, address, ctx, func(page *types.UnbondingDelegationsResponse) {
+		response.UnbondingResponses = append(response.UnbondingResponses, page.UnbondingResponses...)
+	})
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
 
-	url := fmt.Sprintf(
-		"%s/cosmos/bank/v1beta1/balances/%s",
-		rpc.URL,
+func (rpc *RPC) GetRedelegations(
+	address string,
+	ctx context.Context,
+) (*types.RedelegationsResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/staking/v1beta1/delegators/%s/redelegations",
 		address,
 	)
 
-	var response *types.BalanceResponse
-	queryInfo, header, err := rpc.Client.Get(url, &response, types.HTTPPredicateCheckHeightAfter(lastHeight), ctx)
+	response := &types.RedelegationsResponse{}
+	queryInfo, err := getAllPages(rpc, path, address, ctx, func(page *types.RedelegationsResponse) {
+		response.RedelegationResponses = append(response.RedelegationResponses, page.RedelegationResponses...)
+	})
 	if err != nil {
 		return nil, queryInfo, err
 	}
 
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetDelegatorRewards(
+	address string,
+	ctx context.Context,
+) (*types.DelegatorRewardsResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/distribution/v1beta1/delegators/%s/rewards",
+		address,
+	)
+
+	var response *types.DelegatorRewardsResponse
+	queryInfo, err := rpc.Get(path, address, &response, ctx)
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetValidatorCommission(
+	validatorAddress string,
+	ctx context.Context,
+) (*types.ValidatorCommissionResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/cosmos/distribution/v1beta1/validators/%s/commission",
+		validatorAddress,
+	)
+
+	var response *types.ValidatorCommissionResponse
+	queryInfo, err := rpc.Get(path, validatorAddress, &response, ctx)
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetDenomTrace(
+	denom string,
+	ctx context.Context,
+) (*types.DenomTraceResponse, types.QueryInfo, error) {
+	path := fmt.Sprintf(
+		"/ibc/apps/transfer/v1/denom_traces/%s",
+		strings.TrimPrefix(denom, types.IBCDenomPrefix),
+	)
+
+	var response *types.DenomTraceResponse
+	queryInfo, _, err := rpc.query(path, &response, types.HTTPPredicateAlwaysPass(), ctx)
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetCW20Balance(
+	contract string,
+	address string,
+	ctx context.Context,
+) (*types.CW20BalanceResponse, types.QueryInfo, error) {
+	query, err := json.Marshal(map[string]interface{}{
+		"balance": map[string]string{"address": address},
+	})
+	if err != nil {
+		return nil, types.QueryInfo{}, err
+	}
+
+	// URL-safe encoding, as the standard one can contain slashes, which would break the path
+	path := fmt.Sprintf(
+		"/cosmwasm/wasm/v1/contract/%s/smart/%s",
+		contract,
+		base64.URLEncoding.EncodeToString(query),
+	)
+
+	var response *types.CW20BalanceResponse
+	queryInfo, err := rpc.Get(path, address, &response, ctx)
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetDenomsMetadata(ctx context.Context) (*types.DenomsMetadataResponse, types.QueryInfo, error) {
+	path := "/cosmos/bank/v1beta1/denoms_metadata"
+
+	// not bound to an address, so the path is used as the key for block height checks
+	response := &types.DenomsMetadataResponse{}
+	queryInfo, err := getAllPages(rpc, path, path, ctx, func(page *types.DenomsMetadataResponse) {
+		response.Metadatas = append(response.Metadatas, page.Metadatas...)
+	})
+	if err != nil {
+		return nil, queryInfo, err
+	}
+
+	return response, queryInfo, nil
+}
+
+func (rpc *RPC) GetBondDenom(ctx context.Context) (string, *types.QueryInfo, error) {
+	rpc.Mutex.Lock()
+	bondDenom := rpc.BondDenom
+	rpc.Mutex.Unlock()
+
+	if bondDenom != "" {
+		return bondDenom, nil, nil
+	}
+
+	var response *types.StakingParamsResponse
+	queryInfo, _, err := rpc.query("/cosmos/staking/v1beta1/params", &response, types.HTTPPredicateAlwaysPass(), ctx)
+	if err != nil {
+		return "", &queryInfo, err
+	}
+
+	rpc.Mutex.Lock()
+	rpc.BondDenom = response.Params.BondDenom
+	rpc.Mutex.Unlock()
+
+	return response.Params.BondDenom, &queryInfo, nil
+}
+
+func (rpc *RPC) Get(
+	path string,
+	address string,
+	target interface{},
+	ctx context.Context,
+) (types.QueryInfo, error) {
+	rpc.Mutex.Lock()
+	lastHeight := rpc.LastQueryHeight[address]
+	rpc.Mutex.Unlock()
+
+	queryInfo, header, err := rpc.query(path, target, types.HTTPPredicateCheckHeightAfter(lastHeight), ctx)
+	if err != nil {
+		return queryInfo, err
+	}
+
 	newLastHeight, _ := utils.GetBlockHeightFromHeader(header)
+	queryInfo.Height = newLastHeight
 
 	rpc.Mutex.Lock()
-	rpc.LastQueryHeight[address] = newLastHeight
+	if newLastHeight > rpc.LastQueryHeight[address] {
+		rpc.LastQueryHeight[address] = newLastHeight
+	}
 	rpc.Mutex.Unlock()
 
-	return response, queryInfo, nil
+	return queryInfo, nil
+}
+
+// query does a request to the endpoints one by one until one of them succeeds,
+// returning the query info of the last attempt.
+func (rpc *RPC) query(
+	path string,
+	target interface{},
+	predicate types.HTTPPredicate,
+	ctx context.Context,
+) (types.QueryInfo, nethttp.Header, error) {
+	rpc.Mutex.Lock()
+	endpoints := rpc.getEndpointsToQuery()
+	rpc.Mutex.Unlock()
+
+	if len(endpoints) == 0 {
+		return types.QueryInfo{Chain: rpc.Chain, URL: path}, nil, errors.New("no LCD endpoints configured")
+	}
+
+	var (
+		queryInfo types.QueryInfo
+		header    nethttp.Header
+		err       error
+	)
+
+	for index, endpoint := range endpoints {
+		// if the endpoint is load-balanced, retrying the query on it may get
+		// a response from another backend that is in sync
+		for attempt := 0; attempt <= HeightRegressionRetries; attempt++ {
+			queryInfo, header, err = rpc.Client.Get(endpoint.URL+path, target, predicate, ctx)
+			queryInfo.Endpoint = endpoint.URL
+
+			height, _ := utils.GetBlockHeightFromHeader(header)
+			rpc.reportQuery(endpoint, queryInfo, height)
+
+			var regressionErr *types.HeightRegressionError
+			if !errors.As(err, &regressionErr) {
+				break
+			}
+
+			rpc.reportHeightRegression(endpoint)
+
+			rpc.Logger.Warn().
+				Err(err).
+				Str("endpoint", endpoint.URL).
+				Str("path", path).
+				Int("attempt", attempt).
+				Msg("Got height regression from endpoint")
+		}
+
+		if err == nil {
+			if index > 0 {
+				rpc.stickToEndpoint(endpoint)
+			}
+
+			return queryInfo, header, nil
+		}
+
+		rpc.Logger.Warn().
+			Err(err).
+			Str("endpoint", endpoint.URL).
+			Str("path", path).
+			Msg("Error querying endpoint, trying next one")
+	}
+
+	return queryInfo, header, err
 }
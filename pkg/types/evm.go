@@ -0,0 +1,29 @@
+package types
+
+import "fmt"
+
+type JSONRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	ID      int           `json:"id"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+}
+
+type JSONRPCError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *JSONRPCError) Error() string {
+	return fmt.Sprintf("JSON-RPC error %d: %s", e.Code, e.Message)
+}
+
+type JSONRPCResponse struct {
+	Result string        `json:"result"`
+	Error  *JSONRPCError `json:"error"`
+}
+
+type EVMCall struct {
+	To   string `json:"to"`
+	Data string `json:"data"`
+}
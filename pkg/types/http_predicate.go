@@ -8,12 +8,40 @@ import (
 
 type HTTPPredicate func(response *http.Response) error
 
+// HeightRegressionError is returned when a node returns data for an older block
+// than the one returned previously, which usually happens with load-balanced LCDs
+// which backends are not in sync.
+type HeightRegressionError struct {
+	PreviousHeight int64
+	CurrentHeight  int64
+}
+
+func (e *HeightRegressionError) Error() string {
+	return fmt.Sprintf(
+		"previous height (%d) is bigger than the current height (%d)",
+		e.PreviousHeight,
+		e.CurrentHeight,
+	)
+}
+
 func HTTPPredicateAlwaysPass() HTTPPredicate {
 	return func(response *http.Response) error {
 		return nil
 	}
 }
 
+// HTTPPredicateCheckStatusOK fails on non-2xx responses, for the APIs that don't return
+// a JSON error body to detect failures by.
+func HTTPPredicateCheckStatusOK() HTTPPredicate {
+	return func(response *http.Response) error {
+		if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+			return fmt.Errorf("got unexpected status code: %d", response.StatusCode)
+		}
+
+		return nil
+	}
+}
+
 func HTTPPredicateCheckHeightAfter(prevHeight int64) HTTPPredicate {
 	return func(response *http.Response) error {
 		currentHeight, err := utils.GetBlockHeightFromHeader(response.Header)
@@ -22,11 +50,7 @@ func HTTPPredicateCheckHeightAfter(prevHeight int64) HTTPPredicate {
 		}
 
 		if prevHeight > currentHeight {
-			return fmt.Errorf(
-				"previous height (%d) is bigger than the current height (%d)",
-				prevHeight,
-				currentHeight,
-			)
+			return &HeightRegressionError{PreviousHeight: prevHeight, CurrentHeight: currentHeight}
 		}
 
 		return nil
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -36,7 +37,14 @@ func TestHTTPPredicateCheckHeightAfterOlderBlock(t *testing.T) {
 		constants.HeaderBlockHeight: []string{"1"},
 	}
 	request := &http.Response{Header: header}
-	require.Error(t, predicate(request))
+	err := predicate(request)
+	require.Error(t, err)
+	require.EqualError(t, err, "previous height (100) is bigger than the current height (1)")
+
+	var regressionErr *HeightRegressionError
+	require.ErrorAs(t, err, &regressionErr)
+	assert.Equal(t, int64(100), regressionErr.PreviousHeight)
+	assert.Equal(t, int64(1), regressionErr.CurrentHeight)
 }
 
 func TestHTTPPredicateCheckHeightPass(t *testing.T) {
@@ -0,0 +1,62 @@
+package types
+
+import (
+	"context"
+	"main/pkg/config"
+	"time"
+)
+
+type ChainDenom struct {
+	Chain     string
+	DenomInfo config.DenomInfo
+}
+
+type PriceKey struct {
+	Chain    string
+	Denom    string
+	Currency string
+}
+
+type Price struct {
+	Value     float64
+	Source    string
+	UpdatedAt time.Time
+}
+
+type Prices map[PriceKey]Price
+
+func (p Prices) Get(chain string, denom string, currency string) (Price, bool) {
+	price, ok := p[PriceKey{Chain: chain, Denom: denom, Currency: currency}]
+	return price, ok
+}
+
+func (p Prices) Set(chain string, denom string, currency string, price Price) {
+	p[PriceKey{Chain: chain, Denom: denom, Currency: currency}] = price
+}
+
+func (p Prices) HasAll(chain string, denom string, currencies []string) bool {
+	for _, currency := range currencies {
+		if _, ok := p.Get(chain, denom, currency); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+type PriceProvider interface {
+	Name() string
+	GetPrices(denoms []ChainDenom, currencies []string, ctx context.Context) (Prices, []QueryInfo, error)
+}
+
+// DerivedPriceProvider is a PriceProvider that calculates prices based on
+// the prices of other denoms, already fetched by previous providers.
+type DerivedPriceProvider interface {
+	PriceProvider
+	GetDerivedPrices(
+		denoms []ChainDenom,
+		currencies []string,
+		knownPrices Prices,
+		ctx context.Context,
+	) (Prices, []QueryInfo, error)
+}
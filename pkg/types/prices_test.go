@@ -0,0 +1,26 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrices(t *testing.T) {
+	t.Parallel()
+
+	prices := Prices{}
+	prices.Set("chain", "uatom", "usd", Price{Value: 5.84, Source: "coingecko"})
+
+	price, found := prices.Get("chain", "uatom", "usd")
+	assert.True(t, found)
+	assert.InDelta(t, 5.84, price.Value, 0.001)
+	assert.Equal(t, "coingecko", price.Source)
+
+	_, found = prices.Get("chain", "uatom", "eur")
+	assert.False(t, found)
+
+	assert.True(t, prices.HasAll("chain", "uatom", []string{"usd"}))
+	assert.False(t, prices.HasAll("chain", "uatom", []string{"usd", "eur"}))
+	assert.False(t, prices.HasAll("chain", "ustake", []string{"usd"}))
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"context"
 	"main/pkg/config"
+	"strings"
 	"time"
 
 	"cosmossdk.io/math"
@@ -16,23 +17,255 @@ type Balance struct {
 
 type Balances []Balance
 
+func (b Balances) Add(balance Balance) Balances {
+	for index, existing := range b {
+		if existing.Denom == balance.Denom {
+			b[index].Amount = existing.Amount.Add(balance.Amount)
+			return b
+		}
+	}
+
+	return append(b, balance)
+}
+
+func (b Balances) Find(denom string) (Balance, bool) {
+	for _, balance := range b {
+		if balance.Denom == denom {
+			return balance, true
+		}
+	}
+
+	return Balance{}, false
+}
+
+// Pagination is a pagination info returned by list queries.
+type Pagination struct {
+	NextKey []byte `json:"next_key"`
+}
+
+func (p Pagination) GetNextKey() []byte {
+	return p.NextKey
+}
+
+type PaginatedResponse interface {
+	GetNextKey() []byte
+}
+
 type BalanceResponse struct {
-	Balances Balances `json:"balances"`
+	Balances   Balances `json:"balances"`
+	Pagination `json:"pagination"`
+}
+
+type DelegationResponse struct {
+	Balance Balance `json:"balance"`
+}
+
+type DelegationsResponse struct {
+	DelegationResponses []DelegationResponse `json:"delegation_responses"`
+	Pagination          `json:"pagination"`
+}
+
+type UnbondingDelegationEntry struct {
+	Balance math.LegacyDec `json:"balance"`
+}
+
+type UnbondingDelegation struct {
+	Entries []UnbondingDelegationEntry `json:"entries"`
+}
+
+type UnbondingDelegationsResponse struct {
+	UnbondingResponses []UnbondingDelegation `json:"unbonding_responses"`
+	Pagination         `json:"pagination"`
+}
+
+type RedelegationEntry struct {
+	Balance math.LegacyDec `json:"balance"`
+}
+
+type Redelegation struct {
+	Entries []RedelegationEntry `json:"entries"`
+}
+
+type RedelegationsResponse struct {
+	RedelegationResponses []Redelegation `json:"redelegation_responses"`
+	Pagination            `json:"pagination"`
+}
+
+type StakingParams struct {
+	BondDenom string `json:"bond_denom"`
+}
+
+type StakingParamsResponse struct {
+	Params StakingParams `json:"params"`
+}
+
+type DelegatorRewardsResponse struct {
+	Total Balances `json:"total"`
+}
+
+type ValidatorCommission struct {
+	Commission Balances `json:"commission"`
+}
+
+type ValidatorCommissionResponse struct {
+	Commission ValidatorCommission `json:"commission"`
+}
+
+const IBCDenomPrefix = "ibc/"
+
+func IsIBCDenom(denom string) bool {
+	return strings.HasPrefix(denom, IBCDenomPrefix)
+}
+
+type DenomTrace struct {
+	Path      string `json:"path"`
+	BaseDenom string `json:"base_denom"`
+}
+
+type DenomTraceResponse struct {
+	DenomTrace DenomTrace `json:"denom_trace"`
+}
+
+const (
+	TokenTypeNative = "native"
+	TokenTypeCW20   = "cw20"
+	TokenTypeEVM    = "evm"
+	TokenTypeERC20  = "erc20"
+)
+
+// CW20BalanceResponse is a CW20 contract response to a {"balance":{"address":...}} smart query.
+type CW20BalanceResponse struct {
+	Data struct {
+		Balance math.LegacyDec `json:"balance"`
+	} `json:"data"`
+}
+
+type DenomUnit struct {
+	Denom    string   `json:"denom"`
+	Exponent int      `json:"exponent"`
+	Aliases  []string `json:"aliases"`
+}
+
+type DenomMetadata struct {
+	Description string      `json:"description"`
+	DenomUnits  []DenomUnit `json:"denom_units"`
+	Base        string      `json:"base"`
+	Display     string      `json:"display"`
+	Name        string      `json:"name"`
+	Symbol      string      `json:"symbol"`
+}
+
+// GetDisplayExponent returns the exponent of the display denom unit,
+// or false if the metadata does not have it.
+func (m DenomMetadata) GetDisplayExponent() (int, bool) {
+	for _, unit := range m.DenomUnits {
+		if unit.Denom == m.Display {
+			return unit.Exponent, true
+		}
+	}
+
+	return 0, false
+}
+
+type DenomsMetadataResponse struct {
+	Pagination
+	Metadatas []DenomMetadata `json:"metadatas"`
+}
+
+type ABCIQueryResult struct {
+	Code   uint32 `json:"code"`
+	Log    string `json:"log"`
+	Value  []byte `json:"value"`
+	Height int64  `json:"height,string"`
+}
+
+type ABCIQueryResponse struct {
+	Result struct {
+		Response ABCIQueryResult `json:"response"`
+	} `json:"result"`
 }
 
 type WalletBalanceEntry struct {
-	Chain    string
-	Success  bool
-	Duration time.Duration
-	Wallet   config.Wallet
-	Balances Balances
+	Chain         string
+	Success       bool
+	Duration      time.Duration
+	Wallet        config.Wallet
+	Balances      Balances
+	Delegations   Balances
+	Unbondings    Balances
+	Redelegations Balances
+	Rewards       Balances
+	Commission    Balances
+	Account       *Account
+	Spendable     Balances
+	CW20Balances  Balances
+	EVMBalances   Balances
+	ERC20Balances Balances
+	Endpoint      string
+	UpdatedAt     time.Time
+	// Height is the block height the balances were fetched at, 0 if unknown.
+	Height int64
+}
+
+// GetValueTokenBalances returns the wallet balances to count the wallet value from,
+// native, CW20 and ERC-20 ones, without the native EVM balance, as on Cosmos EVM chains
+// it's the same funds as the bank balance of its denom.
+func (e WalletBalanceEntry) GetValueTokenBalances() Balances {
+	balances := make(Balances, 0, len(e.Balances)+len(e.CW20Balances)+len(e.ERC20Balances))
+	balances = append(balances, e.Balances...)
+	balances = append(balances, e.CW20Balances...)
+	balances = append(balances, e.ERC20Balances...)
+	return balances
+}
+
+// GetAllTokenBalances returns the wallet balances of all token types,
+// native, CW20, EVM and ERC-20 ones.
+func (e WalletBalanceEntry) GetAllTokenBalances() Balances {
+	balances := make(Balances, 0, len(e.Balances)+len(e.CW20Balances)+len(e.EVMBalances)+len(e.ERC20Balances))
+	balances = append(balances, e.Balances...)
+	balances = append(balances, e.CW20Balances...)
+	balances = append(balances, e.EVMBalances...)
+	balances = append(balances, e.ERC20Balances...)
+	return balances
+}
+
+// BalanceSample is a wallet balances snapshot used to calculate its spend rate, with the amounts
+// already converted to display denoms, so it's done once per poll and not on each scrape.
+type BalanceSample struct {
+	UpdatedAt time.Time
+	// Amounts are the wallet amounts in tokens, keyed by display denom.
+	Amounts map[string]float64
 }
 
 type QueryInfo struct {
 	Chain    string
 	Success  bool
 	URL      string
+	Endpoint string
 	Duration time.Duration
+	Pages    int
+	// Height is the block height the response was returned for, 0 if unknown.
+	Height int64
+}
+
+type EndpointStatus struct {
+	URL               string
+	Successes         int64
+	Failures          int64
+	Latency           time.Duration
+	Height            int64
+	Healthy           bool
+	HeightRegressions int64
+}
+
+// BalanceFetcher fetches wallet balances using one of the transports (LCD, gRPC etc.).
+type BalanceFetcher interface {
+	GetWalletBalances(address string, ctx context.Context) (*BalanceResponse, QueryInfo, error)
+}
+
+// ChainQueryListener is notified after each chain poll, once the state is updated.
+type ChainQueryListener interface {
+	OnChainQueried(ctx context.Context, chain config.Chain)
 }
 
 type Querier interface {
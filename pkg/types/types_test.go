@@ -0,0 +1,71 @@
+package types
+
+import (
+	"testing"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBalancesAdd(t *testing.T) {
+	t.Parallel()
+
+	balances := Balances{}
+	balances = balances.Add(Balance{Denom: "uatom", Amount: math.LegacyNewDec(1)})
+	balances = balances.Add(Balance{Denom: "ustake", Amount: math.LegacyNewDec(2)})
+	balances = balances.Add(Balance{Denom: "uatom", Amount: math.LegacyNewDec(3)})
+
+	require.Len(t, balances, 2)
+	assert.Equal(t, "uatom", balances[0].Denom)
+	assert.True(t, balances[0].Amount.Equal(math.LegacyNewDec(4)))
+	assert.Equal(t, "ustake", balances[1].Denom)
+	assert.True(t, balances[1].Amount.Equal(math.LegacyNewDec(2)))
+}
+
+func TestBalancesFind(t *testing.T) {
+	t.Parallel()
+
+	balances := Balances{{Denom: "uatom", Amount: math.LegacyNewDec(1)}}
+
+	balance, found := balances.Find("uatom")
+	require.True(t, found)
+	assert.True(t, balance.Amount.Equal(math.LegacyNewDec(1)))
+
+	_, found = balances.Find("ustake")
+	assert.False(t, found)
+}
+
+func TestWalletBalanceEntryGetAllTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	entry := WalletBalanceEntry{
+		Balances:      Balances{{Denom: "uatom"}},
+		CW20Balances:  Balances{{Denom: "cw20:contract"}},
+		EVMBalances:   Balances{{Denom: "aevmos"}},
+		ERC20Balances: Balances{{Denom: "erc20:0xcontract"}},
+	}
+
+	balances := entry.GetAllTokenBalances()
+	require.Len(t, balances, 4)
+	assert.Equal(t, "uatom", balances[0].Denom)
+	assert.Equal(t, "erc20:0xcontract", balances[3].Denom)
+	assert.Len(t, entry.Balances, 1)
+}
+
+func TestWalletBalanceEntryGetValueTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	entry := WalletBalanceEntry{
+		Balances:      Balances{{Denom: "aevmos"}},
+		CW20Balances:  Balances{{Denom: "cw20:contract"}},
+		EVMBalances:   Balances{{Denom: "aevmos"}},
+		ERC20Balances: Balances{{Denom: "erc20:0xcontract"}},
+	}
+
+	balances := entry.GetValueTokenBalances()
+	require.Len(t, balances, 3)
+	assert.Equal(t, "aevmos", balances[0].Denom)
+	assert.Equal(t, "cw20:contract", balances[1].Denom)
+	assert.Equal(t, "erc20:0xcontract", balances[2].Denom)
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"time"
+
+	"cosmossdk.io/math"
+)
+
+const (
+	ContinuousVestingAccountType = "/cosmos.vesting.v1beta1.ContinuousVestingAccount"
+	DelayedVestingAccountType    = "/cosmos.vesting.v1beta1.DelayedVestingAccount"
+	PeriodicVestingAccountType   = "/cosmos.vesting.v1beta1.PeriodicVestingAccount"
+	PermanentLockedAccountType   = "/cosmos.vesting.v1beta1.PermanentLockedAccount"
+)
+
+type BaseVestingAccount struct {
+	OriginalVesting  Balances `json:"original_vesting"`
+	DelegatedFree    Balances `json:"delegated_free"`
+	DelegatedVesting Balances `json:"delegated_vesting"`
+	EndTime          int64    `json:"end_time,string"`
+}
+
+type VestingPeriod struct {
+	Length int64    `json:"length,string"`
+	Amount Balances `json:"amount"`
+}
+
+type Account struct {
+	Type               string              `json:"@type"`
+	BaseVestingAccount *BaseVestingAccount `json:"base_vesting_account"`
+	StartTime          int64               `json:"start_time,string"`
+	VestingPeriods     []VestingPeriod     `json:"vesting_periods"`
+}
+
+type AccountResponse struct {
+	Account Account `json:"account"`
+}
+
+func (a Account) IsVesting() bool {
+	return a.BaseVestingAccount != nil
+}
+
+// GetLockedCoins returns the amount of tokens that are still vesting at the given time,
+// the same way cosmos-sdk x/auth/vesting calculates it for each account type.
+func (a Account) GetLockedCoins(now time.Time) Balances {
+	if !a.IsVesting() {
+		return Balances{}
+	}
+
+	original := a.BaseVestingAccount.OriginalVesting
+	nowUnix := now.Unix()
+
+	switch a.Type {
+	case ContinuousVestingAccountType:
+		if nowUnix <= a.StartTime {
+			return original
+		}
+
+		if nowUnix >= a.BaseVestingAccount.EndTime {
+			return Balances{}
+		}
+
+		elapsed := nowUnix - a.StartTime
+		total := a.BaseVestingAccount.EndTime - a.StartTime
+
+		locked := make(Balances, len(original))
+		for index, balance := range original {
+			vested := balance.Amount.MulInt64(elapsed).QuoInt64(total).TruncateDec()
+			locked[index] = Balance{Denom: balance.Denom, Amount: balance.Amount.Sub(vested)}
+		}
+
+		return locked
+	case PeriodicVestingAccountType:
+		if nowUnix <= a.StartTime {
+			return original
+		}
+
+		if nowUnix >= a.BaseVestingAccount.EndTime {
+			return Balances{}
+		}
+
+		vested := Balances{}
+		periodEnd := a.StartTime
+
+		for _, period := range a.VestingPeriods {
+			periodEnd += period.Length
+			if nowUnix < periodEnd {
+				break
+			}
+
+			for _, balance := range period.Amount {
+				vested = vested.Add(balance)
+			}
+		}
+
+		locked := make(Balances, len(original))
+		for index, balance := range original {
+			vestedAmount := math.LegacyZeroDec()
+			for _, vestedBalance := range vested {
+				if vestedBalance.Denom == balance.Denom {
+					vestedAmount = vestedBalance.Amount
+				}
+			}
+
+			locked[index] = Balance{Denom: balance.Denom, Amount: balance.Amount.Sub(vestedAmount)}
+		}
+
+		return locked
+	case PermanentLockedAccountType:
+		return original
+	default:
+		// delayed vesting account, as well as other ones based on BaseVestingAccount,
+		// with everything locked until the end time
+		if nowUnix < a.BaseVestingAccount.EndTime {
+			return original
+		}
+
+		return Balances{}
+	}
+}
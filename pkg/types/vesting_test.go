@@ -0,0 +1,93 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"cosmossdk.io/math"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newVestingAccount(accountType string) Account {
+	return Account{
+		Type: accountType,
+		BaseVestingAccount: &BaseVestingAccount{
+			OriginalVesting: Balances{{Denom: "uatom", Amount: math.LegacyNewDec(1000)}},
+			EndTime:         2000,
+		},
+		StartTime: 1000,
+	}
+}
+
+func TestAccountNotVesting(t *testing.T) {
+	t.Parallel()
+
+	account := Account{Type: "/cosmos.auth.v1beta1.BaseAccount"}
+	assert.False(t, account.IsVesting())
+	assert.Empty(t, account.GetLockedCoins(time.Unix(1500, 0)))
+}
+
+func TestAccountContinuousVesting(t *testing.T) {
+	t.Parallel()
+
+	account := newVestingAccount(ContinuousVestingAccountType)
+	assert.True(t, account.IsVesting())
+
+	before := account.GetLockedCoins(time.Unix(500, 0))
+	require.Len(t, before, 1)
+	assert.Equal(t, int64(1000), before[0].Amount.TruncateInt64())
+
+	middle := account.GetLockedCoins(time.Unix(1250, 0))
+	require.Len(t, middle, 1)
+	assert.Equal(t, "uatom", middle[0].Denom)
+	assert.Equal(t, int64(750), middle[0].Amount.TruncateInt64())
+
+	assert.Empty(t, account.GetLockedCoins(time.Unix(2500, 0)))
+}
+
+func TestAccountDelayedVesting(t *testing.T) {
+	t.Parallel()
+
+	account := newVestingAccount(DelayedVestingAccountType)
+
+	middle := account.GetLockedCoins(time.Unix(1999, 0))
+	require.Len(t, middle, 1)
+	assert.Equal(t, int64(1000), middle[0].Amount.TruncateInt64())
+
+	assert.Empty(t, account.GetLockedCoins(time.Unix(2000, 0)))
+}
+
+func TestAccountPeriodicVesting(t *testing.T) {
+	t.Parallel()
+
+	account := newVestingAccount(PeriodicVestingAccountType)
+	account.VestingPeriods = []VestingPeriod{
+		{Length: 500, Amount: Balances{{Denom: "uatom", Amount: math.LegacyNewDec(400)}}},
+		{Length: 500, Amount: Balances{{Denom: "uatom", Amount: math.LegacyNewDec(600)}}},
+	}
+
+	before := account.GetLockedCoins(time.Unix(1000, 0))
+	require.Len(t, before, 1)
+	assert.Equal(t, int64(1000), before[0].Amount.TruncateInt64())
+
+	firstPeriod := account.GetLockedCoins(time.Unix(1499, 0))
+	require.Len(t, firstPeriod, 1)
+	assert.Equal(t, int64(1000), firstPeriod[0].Amount.TruncateInt64())
+
+	secondPeriod := account.GetLockedCoins(time.Unix(1500, 0))
+	require.Len(t, secondPeriod, 1)
+	assert.Equal(t, int64(600), secondPeriod[0].Amount.TruncateInt64())
+
+	assert.Empty(t, account.GetLockedCoins(time.Unix(2000, 0)))
+}
+
+func TestAccountPermanentLocked(t *testing.T) {
+	t.Parallel()
+
+	account := newVestingAccount(PermanentLockedAccountType)
+
+	locked := account.GetLockedCoins(time.Unix(5000, 0))
+	require.Len(t, locked, 1)
+	assert.Equal(t, int64(1000), locked[0].Amount.TruncateInt64())
+}
@@ -1,9 +1,15 @@
 package utils
 
 import (
+	"errors"
+	"fmt"
 	"main/pkg/constants"
+	"math/big"
 	"net/http"
 	"strconv"
+	"strings"
+
+	"cosmossdk.io/math"
 )
 
 func BoolToFloat64(b bool) float64 {
@@ -27,3 +33,31 @@ func GetBlockHeightFromHeader(header http.Header) (int64, error) {
 
 	return value, nil
 }
+
+// ToDisplayAmount divides the amount in base units by 10^exponent using big integers,
+// so it never loses precision or panics on overflow as float64 or decimal division might.
+// The result is truncated to the 18 decimal places a decimal has.
+func ToDisplayAmount(amount math.LegacyDec, exponent int) (math.LegacyDec, error) {
+	if amount.IsNil() {
+		return math.LegacyDec{}, errors.New("amount is nil")
+	}
+
+	if exponent < 0 {
+		return math.LegacyDec{}, fmt.Errorf("exponent cannot be negative: %d", exponent)
+	}
+
+	divisor := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exponent)), nil)
+	quotient := new(big.Int).Quo(amount.BigInt(), divisor)
+
+	return math.LegacyNewDecFromBigIntWithPrec(quotient, math.LegacyPrecision), nil
+}
+
+// FormatAmount returns the exact amount as a string, without the trailing decimal zeros,
+// so integer amounts are returned as integers.
+func FormatAmount(amount math.LegacyDec) string {
+	if amount.IsInteger() {
+		return amount.TruncateInt().String()
+	}
+
+	return strings.TrimRight(amount.String(), "0")
+}
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"testing"
 
+	"cosmossdk.io/math"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -48,3 +49,35 @@ func TestGetBlockFromHeaderValidValue(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int64(123), value)
 }
+
+func TestToDisplayAmount(t *testing.T) {
+	t.Parallel()
+
+	amount, err := ToDisplayAmount(math.LegacyMustNewDecFromStr("123456789123456789123456789"), 18)
+	require.NoError(t, err)
+	assert.Equal(t, "123456789.123456789123456789", amount.String())
+
+	amount, err = ToDisplayAmount(math.LegacyNewDec(1500000), 0)
+	require.NoError(t, err)
+	assert.Equal(t, "1500000.000000000000000000", amount.String())
+
+	// truncated to 18 decimal places
+	amount, err = ToDisplayAmount(math.LegacyNewDec(1), 20)
+	require.NoError(t, err)
+	assert.True(t, amount.IsZero())
+
+	_, err = ToDisplayAmount(math.LegacyDec{}, 6)
+	require.ErrorContains(t, err, "amount is nil")
+
+	_, err = ToDisplayAmount(math.LegacyNewDec(1), -1)
+	require.ErrorContains(t, err, "exponent cannot be negative")
+}
+
+func TestFormatAmount(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "123456789123456789123456789", FormatAmount(math.LegacyMustNewDecFromStr("123456789123456789123456789")))
+	assert.Equal(t, "1.5", FormatAmount(math.LegacyMustNewDecFromStr("1.5")))
+	assert.Equal(t, "0.000000000000000001", FormatAmount(math.LegacyMustNewDecFromStr("0.000000000000000001")))
+	assert.Equal(t, "0", FormatAmount(math.LegacyZeroDec()))
+}